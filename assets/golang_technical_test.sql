@@ -1,20 +1,94 @@
 CREATE DATABASE IF NOT EXISTS golang_technical_test;
 USE golang_technical_test;
 
+-- Departments Table
+CREATE TABLE Departments (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL,
+    Code VARCHAR(20) NOT NULL UNIQUE
+);
+
 -- Professors Table
 CREATE TABLE Professors (
     ID INT AUTO_INCREMENT PRIMARY KEY,
     Name VARCHAR(255),
     Lastname VARCHAR(255),
     Email VARCHAR(255),
-    Specialization VARCHAR(255)
+    Specialization VARCHAR(255),
+    DepartmentID INT NULL,
+    FOREIGN KEY (DepartmentID) REFERENCES Departments(ID)
+);
+
+-- GradingScales Table (named scales that give meaning to numeric grades)
+CREATE TABLE GradingScales (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL UNIQUE,
+    MinGrade DECIMAL(5,2) NOT NULL,
+    MaxGrade DECIMAL(5,2) NOT NULL
 );
 
+-- GradeRanges Table (a range starts at MinGrade and ends where the next one of its scale starts)
+CREATE TABLE GradeRanges (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    ScaleID INT NOT NULL,
+    Letter VARCHAR(10) NOT NULL,
+    MinGrade DECIMAL(5,2) NOT NULL,
+    Passing BOOLEAN NOT NULL,
+    Points DECIMAL(3,2) NULL,
+    UNIQUE (ScaleID, MinGrade),
+    FOREIGN KEY (ScaleID) REFERENCES GradingScales(ID)
+);
+
+-- Default grading scales
+INSERT INTO GradingScales (ID, Name, MinGrade, MaxGrade) VALUES
+    (1, '0-5', 0, 5),
+    (2, '0-100', 0, 100),
+    (3, 'A-F', 0, 100);
+INSERT INTO GradeRanges (ScaleID, Letter, MinGrade, Passing) VALUES
+    (1, 'F', 0, FALSE), (1, 'C', 3, TRUE), (1, 'B', 4, TRUE), (1, 'A', 4.5, TRUE),
+    (2, 'F', 0, FALSE), (2, 'D', 60, TRUE), (2, 'C', 70, TRUE), (2, 'B', 80, TRUE), (2, 'A', 90, TRUE);
+INSERT INTO GradeRanges (ScaleID, Letter, MinGrade, Passing, Points) VALUES
+    (3, 'F', 0, FALSE, 0), (3, 'D-', 60, TRUE, 0.7), (3, 'D', 63, TRUE, 1), (3, 'D+', 67, TRUE, 1.3),
+    (3, 'C-', 70, TRUE, 1.7), (3, 'C', 73, TRUE, 2), (3, 'C+', 77, TRUE, 2.3),
+    (3, 'B-', 80, TRUE, 2.7), (3, 'B', 83, TRUE, 3), (3, 'B+', 87, TRUE, 3.3),
+    (3, 'A-', 90, TRUE, 3.7), (3, 'A', 93, TRUE, 4), (3, 'A+', 97, TRUE, 4);
+
 -- Courses Table
 CREATE TABLE Courses (
     ID INT AUTO_INCREMENT PRIMARY KEY,
     Name VARCHAR(255),
-    Description TEXT
+    Description TEXT,
+    Credits INT NOT NULL DEFAULT 0,
+    GradingScaleID INT NULL,
+    FOREIGN KEY (GradingScaleID) REFERENCES GradingScales(ID)
+);
+
+-- Programs Table (degrees offered by a department)
+CREATE TABLE Programs (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    DepartmentID INT NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    MinCredits INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (DepartmentID) REFERENCES Departments(ID)
+);
+
+-- ProgramCourseSets Table (required or elective groups of courses of a program)
+CREATE TABLE ProgramCourseSets (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    ProgramID INT NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    Kind VARCHAR(20) NOT NULL,
+    MinCredits INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (ProgramID) REFERENCES Programs(ID)
+);
+
+-- ProgramCourseSetCourses Table
+CREATE TABLE ProgramCourseSetCourses (
+    SetID INT NOT NULL,
+    CourseID INT NOT NULL,
+    PRIMARY KEY (SetID, CourseID),
+    FOREIGN KEY (SetID) REFERENCES ProgramCourseSets(ID),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID)
 );
 
 -- Students Table
@@ -24,7 +98,44 @@ CREATE TABLE Students (
     Lastname VARCHAR(255),
     DateOfBirth DATE,
     Address VARCHAR(255),
-    Email VARCHAR(255)
+    Email VARCHAR(255),
+    ProgramID INT NULL,
+    Status VARCHAR(20) NOT NULL DEFAULT 'applicant',
+    FOREIGN KEY (ProgramID) REFERENCES Programs(ID)
+);
+
+-- StudentStatusChanges Table (history of student status changes and their reasons)
+CREATE TABLE StudentStatusChanges (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    StudentID INT NOT NULL,
+    FromStatus VARCHAR(20) NOT NULL,
+    ToStatus VARCHAR(20) NOT NULL,
+    Reason TEXT NOT NULL,
+    ActorUserID INT NULL,
+    ActorUsername VARCHAR(255) NOT NULL,
+    ChangedAt DATETIME NOT NULL,
+    FOREIGN KEY (StudentID) REFERENCES Students(ID)
+);
+
+-- Terms Table (academic periods; at most one is active)
+CREATE TABLE Terms (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL,
+    StartDate DATE NOT NULL,
+    EndDate DATE NOT NULL,
+    Active BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Sections Table (groups of a course in a term, with limited seats)
+CREATE TABLE Sections (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    CourseID INT NOT NULL,
+    TermID INT NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    Capacity INT NOT NULL,
+    Waitlist BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID)
 );
 
 -- Enrollment Table (relationship between Students and Courses)
@@ -32,8 +143,15 @@ CREATE TABLE Enrollment (
     ID INT AUTO_INCREMENT PRIMARY KEY,
     StudentID INT,
     CourseID INT,
+    TermID INT NOT NULL,
+    SectionID INT NULL,
+    Status VARCHAR(20) NOT NULL DEFAULT 'enrolled',
+    UNIQUE (StudentID, CourseID, TermID),
+    INDEX (SectionID, Status),
     FOREIGN KEY (StudentID) REFERENCES Students(ID),
-    FOREIGN KEY (CourseID) REFERENCES Courses(ID)
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID),
+    FOREIGN KEY (SectionID) REFERENCES Sections(ID)
 );
 
 -- Grades Table
@@ -42,8 +160,227 @@ CREATE TABLE Grades (
     StudentID INT,
     CourseID INT,
     ProfessorID INT,
+    TermID INT NOT NULL,
     Grade DECIMAL(5,2),
     FOREIGN KEY (StudentID) REFERENCES Students(ID),
     FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (ProfessorID) REFERENCES Professors(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID)
+);
+
+-- Users Table (accounts allowed to log in to the API)
+CREATE TABLE Users (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Username VARCHAR(255) NOT NULL UNIQUE,
+    Email VARCHAR(255) NULL UNIQUE,
+    PasswordHash VARCHAR(255) NOT NULL,
+    Role VARCHAR(20) NOT NULL DEFAULT 'student',
+    StudentID INT NULL,
+    ProfessorID INT NULL,
+    Disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (StudentID) REFERENCES Students(ID),
     FOREIGN KEY (ProfessorID) REFERENCES Professors(ID)
 );
+
+-- Initial administrator account (password: change-me-now). Change it after the first login.
+INSERT INTO Users (Username, PasswordHash, Role) VALUES ('admin', '$2a$10$DHNkyTjeThl68wIFokkte.NSoq9rIzK/fQTdZRGkE2Z4rYcAW5tK6', 'admin');
+
+-- RefreshTokens Table (single-use refresh tokens, grouped by login session)
+CREATE TABLE RefreshTokens (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    UserID INT NOT NULL,
+    FamilyID VARCHAR(64) NOT NULL,
+    TokenHash CHAR(64) NOT NULL UNIQUE,
+    ExpiresAt DATETIME NOT NULL,
+    UsedAt DATETIME NULL,
+    Revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX (FamilyID),
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- RevokedTokens Table (access tokens denied before their expiry, by JWT ID)
+CREATE TABLE RevokedTokens (
+    JTI VARCHAR(64) PRIMARY KEY,
+    ExpiresAt DATETIME NOT NULL
+);
+
+-- UserTokenRevocations Table (every access token issued to the user up to RevokedBefore is denied)
+CREATE TABLE UserTokenRevocations (
+    UserID INT PRIMARY KEY,
+    RevokedBefore DATETIME NOT NULL,
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- TeachingAssignments Table (which professor teaches which course)
+CREATE TABLE TeachingAssignments (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    ProfessorID INT NOT NULL,
+    CourseID INT NOT NULL,
+    UNIQUE (ProfessorID, CourseID),
+    FOREIGN KEY (ProfessorID) REFERENCES Professors(ID),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID)
+);
+
+-- ApiKeys Table (keys for service integrations; only the SHA-256 of each key is stored)
+CREATE TABLE ApiKeys (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(100) NOT NULL,
+    Prefix VARCHAR(16) NOT NULL,
+    KeyHash CHAR(64) NOT NULL UNIQUE,
+    Scopes VARCHAR(255) NOT NULL,
+    CreatedBy INT NOT NULL,
+    CreatedAt DATETIME NOT NULL,
+    LastUsedAt DATETIME NULL,
+    Revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (CreatedBy) REFERENCES Users(ID)
+);
+
+-- PasswordResetTokens Table (single-use, time-limited password reset links)
+CREATE TABLE PasswordResetTokens (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    UserID INT NOT NULL,
+    TokenHash CHAR(64) NOT NULL UNIQUE,
+    ExpiresAt DATETIME NOT NULL,
+    UsedAt DATETIME NULL,
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- UserTOTP Table (authenticator app secrets for two-factor authentication)
+CREATE TABLE UserTOTP (
+    UserID INT PRIMARY KEY,
+    Secret VARCHAR(64) NOT NULL,
+    Enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    LastStep BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- RecoveryCodes Table (one-time codes to log in without the authenticator app)
+CREATE TABLE RecoveryCodes (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    UserID INT NOT NULL,
+    CodeHash CHAR(64) NOT NULL,
+    UsedAt DATETIME NULL,
+    INDEX (UserID),
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- UserIdentities Table (accounts at the external OpenID Connect provider linked to local users)
+CREATE TABLE UserIdentities (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    UserID INT NOT NULL,
+    Issuer VARCHAR(255) NOT NULL,
+    Subject VARCHAR(255) NOT NULL,
+    UNIQUE (Issuer, Subject),
+    FOREIGN KEY (UserID) REFERENCES Users(ID)
+);
+
+-- AuditLog Table (append-only record of every change to students, courses, professors, grades and enrollments)
+CREATE TABLE AuditLog (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    ActorUserID INT NULL,
+    ActorUsername VARCHAR(255) NOT NULL,
+    ActorRole VARCHAR(100) NOT NULL,
+    Action VARCHAR(10) NOT NULL,
+    Entity VARCHAR(50) NOT NULL,
+    EntityID INT NOT NULL,
+    BeforeData JSON NULL,
+    AfterData JSON NULL,
+    CreatedAt DATETIME NOT NULL,
+    INDEX (Entity, EntityID),
+    INDEX (ActorUserID),
+    INDEX (CreatedAt)
+);
+
+-- Prerequisites Table (courses a student must pass before enrolling in another)
+CREATE TABLE Prerequisites (
+    CourseID INT NOT NULL,
+    PrerequisiteID INT NOT NULL,
+    MinGrade DECIMAL(5,2) NULL,
+    PRIMARY KEY (CourseID, PrerequisiteID),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (PrerequisiteID) REFERENCES Courses(ID)
+);
+
+-- AssessmentComponents Table (weighted parts of a course's final grade, such as exams or homework)
+CREATE TABLE AssessmentComponents (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    CourseID INT NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    Category VARCHAR(100) NOT NULL,
+    Weight DECIMAL(5,2) NOT NULL,
+    MaxScore DECIMAL(7,2) NOT NULL,
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID)
+);
+
+-- AssessmentCategories Table (how many of the lowest scores of a category are left out)
+CREATE TABLE AssessmentCategories (
+    CourseID INT NOT NULL,
+    Category VARCHAR(100) NOT NULL,
+    DropLowest INT NOT NULL,
+    PRIMARY KEY (CourseID, Category),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID)
+);
+
+-- AssessmentScores Table (a student's score in a component in a term)
+CREATE TABLE AssessmentScores (
+    ComponentID INT NOT NULL,
+    StudentID INT NOT NULL,
+    TermID INT NOT NULL,
+    Score DECIMAL(7,2) NOT NULL,
+    PRIMARY KEY (ComponentID, StudentID, TermID),
+    FOREIGN KEY (ComponentID) REFERENCES AssessmentComponents(ID),
+    FOREIGN KEY (StudentID) REFERENCES Students(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID)
+);
+
+-- ClassSessions Table (meetings of a course, optionally of one of its sections)
+CREATE TABLE ClassSessions (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    CourseID INT NOT NULL,
+    TermID INT NOT NULL,
+    SectionID INT NULL,
+    Date DATE NOT NULL,
+    Topic VARCHAR(255) NOT NULL DEFAULT '',
+    INDEX (CourseID, TermID),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID),
+    FOREIGN KEY (SectionID) REFERENCES Sections(ID)
+);
+
+-- AttendanceRecords Table (whether a student attended a session)
+CREATE TABLE AttendanceRecords (
+    SessionID INT NOT NULL,
+    StudentID INT NOT NULL,
+    Status VARCHAR(20) NOT NULL,
+    PRIMARY KEY (SessionID, StudentID),
+    INDEX (StudentID),
+    FOREIGN KEY (SessionID) REFERENCES ClassSessions(ID),
+    FOREIGN KEY (StudentID) REFERENCES Students(ID)
+);
+
+-- Rooms Table (classrooms; Features is a comma-separated list such as 'projector,lab')
+CREATE TABLE Rooms (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(100) NOT NULL,
+    Building VARCHAR(100) NOT NULL DEFAULT '',
+    Capacity INT NOT NULL,
+    Features VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+-- Meetings Table (weekly class times of a course, optionally of one of its sections)
+CREATE TABLE Meetings (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    CourseID INT NOT NULL,
+    TermID INT NOT NULL,
+    SectionID INT NULL,
+    Day VARCHAR(10) NOT NULL,
+    StartTime TIME NOT NULL,
+    EndTime TIME NOT NULL,
+    RoomID INT NULL,
+    INDEX (CourseID, TermID),
+    INDEX (RoomID, TermID),
+    FOREIGN KEY (CourseID) REFERENCES Courses(ID),
+    FOREIGN KEY (TermID) REFERENCES Terms(ID),
+    FOREIGN KEY (SectionID) REFERENCES Sections(ID),
+    FOREIGN KEY (RoomID) REFERENCES Rooms(ID)
+);
@@ -6,6 +6,9 @@ import (
 	"golang-technical-test/internal/delivery/http"
 	"golang-technical-test/internal/repository"
 	"golang-technical-test/internal/usecase"
+	"golang-technical-test/mailer"
+	"golang-technical-test/middlewares"
+	"golang-technical-test/oidc"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -19,36 +22,118 @@ func main() {
 		log.Fatalf("Error loading config: %s", err.Error())
 	}
 
+	// Load the JWT signing keys
+	if err := middlewares.LoadKeys(cfg.Auth.JWT); err != nil {
+		log.Fatalf("Error loading JWT keys: %v", err)
+	}
+
 	// Initialize the database
 	db, err := database.NewDatabase(cfg.DB)
 	if err != nil {
 		log.Fatalf("Error initializing database: %v", err)
 	}
 
+	// Initialize the mailer
+	mail, err := mailer.NewMailer(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Error initializing mailer: %v", err)
+	}
+
 	// Initialize the repositories
 	studentRepo := repository.NewStudentRepository(db)
 	courseRepo := repository.NewCourseRepository(db)
 	professorRepo := repository.NewProfessorRepository(db)
 	gradeRepo := repository.NewGradeRepository(db)
 	enrollmentRepo := repository.NewEnrollmentRepository(db)
+	teachingAssignmentRepo := repository.NewTeachingAssignmentRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	loginAttemptRepo := repository.NewInMemoryLoginAttemptRepository(cfg.Auth.LoginThrottle.FailureWindow)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	passwordResetTokenRepo := repository.NewPasswordResetTokenRepository(db)
+	totpRepo := repository.NewTOTPRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	userIdentityRepo := repository.NewUserIdentityRepository(db)
+	oidcStateRepo := repository.NewInMemoryOIDCStateRepository()
+	auditRepo := repository.NewAuditRepository(db)
+	termRepo := repository.NewTermRepository(db)
+	sectionRepo := repository.NewSectionRepository(db)
+	prerequisiteRepo := repository.NewPrerequisiteRepository(db)
+	gradingScaleRepo := repository.NewGradingScaleRepository(db)
+	assessmentRepo := repository.NewAssessmentRepository(db)
+	attendanceRepo := repository.NewAttendanceRepository(db)
+	meetingRepo := repository.NewMeetingRepository(db)
+	roomRepo := repository.NewRoomRepository(db)
+	departmentRepo := repository.NewDepartmentRepository(db)
+	programRepo := repository.NewProgramRepository(db)
+
+	var revokedTokenRepo repository.IRevokedTokenRepository
+	if cfg.Auth.RevocationStore == "database" {
+		revokedTokenRepo = repository.NewRevokedTokenRepository(db)
+	} else {
+		revokedTokenRepo = repository.NewInMemoryRevokedTokenRepository()
+	}
+	middlewares.SetRevocationStore(revokedTokenRepo)
 
 	// Initialize the usecases
-	studentUsecase := usecase.NewStudentUsecase(studentRepo)
-	courseUsecase := usecase.NewCourseUsecase(courseRepo)
-	professorUsecase := usecase.NewProfessorUsecase(professorRepo)
-	gradeUsecase := usecase.NewGradeUsecase(gradeRepo)
-	enrollmentUsecase := usecase.NewEnrollmentUsecase(enrollmentRepo)
+	studentUsecase := usecase.NewStudentUsecase(studentRepo, auditRepo, db)
+	courseUsecase := usecase.NewCourseUsecase(courseRepo, gradingScaleRepo, auditRepo, db)
+	professorUsecase := usecase.NewProfessorUsecase(professorRepo, departmentRepo, auditRepo, db)
+	gradeUsecase := usecase.NewGradeUsecase(gradeRepo, teachingAssignmentRepo, termRepo, gradingScaleRepo, studentRepo, auditRepo, db)
+	enrollmentUsecase := usecase.NewEnrollmentUsecase(enrollmentRepo, termRepo, sectionRepo, prerequisiteRepo, gradeRepo, gradingScaleRepo, meetingRepo, studentRepo, auditRepo, db)
+	termUsecase := usecase.NewTermUsecase(termRepo, auditRepo, db)
+	sectionUsecase := usecase.NewSectionUsecase(sectionRepo, courseRepo, termRepo, enrollmentRepo, studentRepo, meetingRepo, auditRepo, db)
+	prerequisiteUsecase := usecase.NewPrerequisiteUsecase(prerequisiteRepo, courseRepo)
+	gradingScaleUsecase := usecase.NewGradingScaleUsecase(gradingScaleRepo, auditRepo, db)
+	assessmentUsecase := usecase.NewAssessmentUsecase(assessmentRepo, courseRepo, gradeRepo, teachingAssignmentRepo, termRepo, gradingScaleRepo, studentRepo, enrollmentRepo, auditRepo, db)
+	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, courseRepo, sectionRepo, enrollmentRepo, termRepo, auditRepo, db, cfg.Academic)
+	meetingUsecase := usecase.NewMeetingUsecase(meetingRepo, courseRepo, sectionRepo, roomRepo, enrollmentRepo, termRepo, auditRepo, db)
+	roomUsecase := usecase.NewRoomUsecase(roomRepo, meetingRepo, termRepo, auditRepo, db)
+	departmentUsecase := usecase.NewDepartmentUsecase(departmentRepo, auditRepo, db)
+	programUsecase := usecase.NewProgramUsecase(programRepo, departmentRepo, courseRepo, studentRepo, gradeRepo, gradingScaleRepo, auditRepo, db)
+	gpaUsecase := usecase.NewGPAUsecase(gradeRepo, courseRepo, termRepo, studentRepo, gradingScaleRepo, cfg.Academic)
+	teachingAssignmentUsecase := usecase.NewTeachingAssignmentUsecase(teachingAssignmentRepo, professorRepo, courseRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo)
+	refreshTokenUsecase := usecase.NewRefreshTokenUsecase(refreshTokenRepo, userRepo)
+	tokenRevocationUsecase := usecase.NewTokenRevocationUsecase(revokedTokenRepo, refreshTokenRepo, userRepo)
+	loginThrottleUsecase := usecase.NewLoginThrottleUsecase(loginAttemptRepo, cfg.Auth.LoginThrottle)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo)
+	middlewares.SetAPIKeyAuthenticator(apiKeyUsecase)
+	mfaUsecase := usecase.NewMFAUsecase(totpRepo, recoveryCodeRepo, userRepo, cfg.Auth.MFA)
+	oidcUsecase := usecase.NewOIDCUsecase(oidc.NewClient(cfg.Auth.OIDC), oidcStateRepo, userIdentityRepo, userRepo)
+	auditUsecase := usecase.NewAuditUsecase(auditRepo)
+	passwordResetUsecase := usecase.NewPasswordResetUsecase(passwordResetTokenRepo, userRepo, refreshTokenRepo, revokedTokenRepo, loginAttemptRepo, mail, cfg.Auth.PasswordReset)
 
 	// Initialize the router
 	router := gin.Default()
+	router.Use(middlewares.JWTAuthMiddleware(cfg.Auth.PublicRoutes...))
 
 	// Initialize the handlers
-	http.NewLoginHandler(router)
-	http.NewStudentHandler(studentUsecase, router)
-	http.NewCourseHandler(courseUsecase, router)
-	http.NewProfessorHandler(professorUsecase, router)
+	http.NewLoginHandler(userUsecase, refreshTokenUsecase, tokenRevocationUsecase, loginThrottleUsecase, mfaUsecase, router)
+	http.NewMFAHandler(mfaUsecase, router)
+	if cfg.Auth.OIDC != nil && cfg.Auth.OIDC.Enabled {
+		http.NewOIDCHandler(oidcUsecase, refreshTokenUsecase, mfaUsecase, router)
+	}
+	http.NewPasswordResetHandler(passwordResetUsecase, router)
+	http.NewUserHandler(userUsecase, router)
+	http.NewTokenHandler(tokenRevocationUsecase, router)
+	http.NewAPIKeyHandler(apiKeyUsecase, router)
+	http.NewAuditHandler(auditUsecase, router)
+	http.NewJWKSHandler(router)
+	http.NewHealthHandler(router)
+	http.NewStudentHandler(studentUsecase, gpaUsecase, attendanceUsecase, meetingUsecase, programUsecase, router)
+	http.NewCourseHandler(courseUsecase, sectionUsecase, prerequisiteUsecase, router)
+	http.NewProfessorHandler(professorUsecase, teachingAssignmentUsecase, router)
 	http.NewGradeHandler(gradeUsecase, router)
 	http.NewEnrollmentHandler(enrollmentUsecase, router)
+	http.NewTermHandler(termUsecase, router)
+	http.NewGradingScaleHandler(gradingScaleUsecase, router)
+	http.NewAssessmentHandler(assessmentUsecase, router)
+	http.NewAttendanceHandler(attendanceUsecase, router)
+	http.NewMeetingHandler(meetingUsecase, router)
+	http.NewRoomHandler(roomUsecase, router)
+	http.NewDepartmentHandler(departmentUsecase, router)
+	http.NewProgramHandler(programUsecase, router)
 
 	// Run the server
 	router.Run(":7777")
@@ -1,9 +1,16 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
-	DB *DBConfig
+	DB       *DBConfig
+	Auth     *AuthConfig
+	Mail     *MailConfig
+	Academic *AcademicConfig
 }
 
 type DBConfig struct {
@@ -15,11 +22,126 @@ type DBConfig struct {
 	Database string
 }
 
+type AuthConfig struct {
+	// RevocationStore selects where revoked access tokens are kept: "memory" or "database".
+	RevocationStore string
+	JWT             *JWTConfig
+	// PublicRoutes can be called without a token, as "METHOD /path" or "/path".
+	PublicRoutes  []string
+	LoginThrottle *LoginThrottleConfig
+	PasswordReset *PasswordResetConfig
+	MFA           *MFAConfig
+	OIDC          *OIDCConfig
+}
+
+// LoginThrottleConfig controls the brute-force protection of /login.
+// Failures are counted per username and per client IP. After DelayAfter
+// failures each new attempt must wait BaseDelay, doubling with every further
+// failure up to MaxDelay. Reaching MaxFailures (or IPMaxFailures for an IP)
+// locks the key for LockoutDuration. Counters are forgotten after
+// FailureWindow without failures.
+type LoginThrottleConfig struct {
+	DelayAfter      int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	MaxFailures     int
+	IPMaxFailures   int
+	LockoutDuration time.Duration
+	FailureWindow   time.Duration
+}
+
+// PasswordResetConfig controls the self-service password reset. The link
+// emailed to the user is URL with the token appended as "?token=".
+// Requests are counted per email and per client IP. Reaching MaxRequests
+// (or IPMaxRequests for an IP) ignores further requests for RequestWindow,
+// and counters are forgotten after RequestWindow without requests.
+type PasswordResetConfig struct {
+	URL           string
+	TokenTTL      time.Duration
+	MaxRequests   int
+	IPMaxRequests int
+	RequestWindow time.Duration
+}
+
+// MFAConfig controls two-factor authentication. Issuer is the name
+// authenticator apps show next to the account.
+type MFAConfig struct {
+	Issuer string
+}
+
+// OIDCConfig enables logging in through an external OpenID Connect provider
+// with the authorization code flow and PKCE. RedirectURL must point to
+// /login/oidc/callback and be registered with the provider.
+type OIDCConfig struct {
+	Enabled      bool
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// MailConfig selects how emails are sent: Driver "smtp" uses Host, Port,
+// Username and Password; "log" appends them to LogFile, or the standard log
+// when it is empty.
+type MailConfig struct {
+	Driver   string
+	From     string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	LogFile  string
+}
+
+// AcademicConfig holds the academic rules. RetakeRule decides which grade
+// of a course taken several times counts for the GPA: "latest", "best" or
+// "average". Students attending less than AttendanceThreshold percent of a
+// course's sessions are flagged at risk.
+type AcademicConfig struct {
+	RetakeRule          string
+	AttendanceThreshold float64
+}
+
+type JWTConfig struct {
+	// SigningKeyID is the ID of the key new tokens are signed with.
+	SigningKeyID string
+	Keys         []JWTKeyConfig
+}
+
+// JWTKeyConfig describes one signing key. HS256 keys use Secret; RS256 and
+// EdDSA keys are read from PEM files. A key with only PublicKeyFile can
+// verify tokens but not sign them.
+type JWTKeyConfig struct {
+	ID             string
+	Algorithm      string
+	Secret         string
+	PrivateKeyFile string
+	PublicKeyFile  string
+}
+
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yml")
 	viper.AddConfigPath("../")
 
+	viper.SetDefault("Auth.RevocationStore", "memory")
+	viper.SetDefault("Auth.LoginThrottle.DelayAfter", 3)
+	viper.SetDefault("Auth.LoginThrottle.BaseDelay", "1s")
+	viper.SetDefault("Auth.LoginThrottle.MaxDelay", "30s")
+	viper.SetDefault("Auth.LoginThrottle.MaxFailures", 10)
+	viper.SetDefault("Auth.LoginThrottle.IPMaxFailures", 50)
+	viper.SetDefault("Auth.LoginThrottle.LockoutDuration", "15m")
+	viper.SetDefault("Auth.LoginThrottle.FailureWindow", "15m")
+	viper.SetDefault("Auth.PasswordReset.TokenTTL", "1h")
+	viper.SetDefault("Auth.PasswordReset.MaxRequests", 3)
+	viper.SetDefault("Auth.PasswordReset.IPMaxRequests", 20)
+	viper.SetDefault("Auth.PasswordReset.RequestWindow", "15m")
+	viper.SetDefault("Auth.MFA.Issuer", "golang-technical-test")
+	viper.SetDefault("Mail.Driver", "log")
+	viper.SetDefault("Academic.RetakeRule", "latest")
+	viper.SetDefault("Academic.AttendanceThreshold", 80)
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
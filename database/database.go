@@ -7,8 +7,11 @@ import (
 )
 
 // Database struct
+// A Database bound to a transaction runs its statements in it; see
+// Transaction.
 type Database struct {
 	*sql.DB
+	tx *sql.Tx
 }
 
 // NewDatabase
@@ -19,5 +22,89 @@ func NewDatabase(config *config.DBConfig) (*Database, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Database{db}, nil
+	return &Database{DB: db}, nil
+}
+
+// ITransactor runs work in a transaction. Usecases depend on it rather than
+// on Database, which implements it.
+type ITransactor interface {
+	Transaction(fn func(tx *Database) error) error
+}
+
+// Transaction runs fn in a transaction, committing it when fn returns nil
+// and rolling it back otherwise. Repositories bound to tx run their
+// statements in the transaction. When d is already bound to one, fn joins
+// it and the outermost Transaction decides the outcome.
+func (d *Database) Transaction(fn func(tx *Database) error) error {
+	tx, err := d.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&Database{DB: d.DB, tx: tx.Tx}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Tx is a transaction started by Begin.
+type Tx struct {
+	*sql.Tx
+	joined bool
+}
+
+// Begin starts a transaction. On a Database bound to a transaction it
+// joins that one instead: Commit and Rollback are then left to its owner.
+func (d *Database) Begin() (*Tx, error) {
+	if d.tx != nil {
+		return &Tx{Tx: d.tx, joined: true}, nil
+	}
+	tx, err := d.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+func (t *Tx) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *Tx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+func (d *Database) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if d.tx != nil {
+		return d.tx.Exec(query, args...)
+	}
+	return d.DB.Exec(query, args...)
+}
+
+func (d *Database) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if d.tx != nil {
+		return d.tx.Query(query, args...)
+	}
+	return d.DB.Query(query, args...)
+}
+
+func (d *Database) QueryRow(query string, args ...interface{}) *sql.Row {
+	if d.tx != nil {
+		return d.tx.QueryRow(query, args...)
+	}
+	return d.DB.QueryRow(query, args...)
+}
+
+func (d *Database) Prepare(query string) (*sql.Stmt, error) {
+	if d.tx != nil {
+		return d.tx.Prepare(query)
+	}
+	return d.DB.Prepare(query)
 }
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	APIKeyUsecase usecase.IAPIKeyUsecase
+	path          string
+}
+
+var (
+	apiKeyHandlerInstance *APIKeyHandler
+	apiKeyHandlerOnce     sync.Once
+)
+
+func NewAPIKeyHandler(apiKeyUsecase usecase.IAPIKeyUsecase, router *gin.Engine) *APIKeyHandler {
+	apiKeyHandlerOnce.Do(func() {
+		apiKeyHandlerInstance = &APIKeyHandler{
+			APIKeyUsecase: apiKeyUsecase,
+			path:          "/api-keys",
+		}
+		apiKeyHandlerInstance.setupRoutes(router)
+	})
+	return apiKeyHandlerInstance
+}
+
+func (h *APIKeyHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+	group.Use(middlewares.RequireRoles(domain.RoleAdmin))
+
+	group.GET("", h.GetAll)
+	group.POST("/create", h.Create)
+	group.PUT("/revoke/:id", h.Revoke)
+}
+
+func (h *APIKeyHandler) GetAll(c *gin.Context) {
+	keys, err := h.APIKeyUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(keys) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No API keys found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// Create returns the new key in clear text. It is the only time it is shown.
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var request struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, plain, err := h.APIKeyUsecase.Create(principalFromContext(c), request.Name, request.Scopes)
+	if err != nil {
+		if err == domain.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"api_key": key, "key": plain})
+}
+
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.APIKeyUsecase.Revoke(id); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssessmentHandler struct {
+	AssessmentUsecase usecase.IAssessmentUsecase
+	path              string
+}
+
+var (
+	assessmentHandlerInstance *AssessmentHandler
+	assessmentHandlerOnce     sync.Once
+)
+
+func NewAssessmentHandler(assessmentUsecase usecase.IAssessmentUsecase, router *gin.Engine) *AssessmentHandler {
+	assessmentHandlerOnce.Do(func() {
+		assessmentHandlerInstance = &AssessmentHandler{
+			AssessmentUsecase: assessmentUsecase,
+			path:              "/courses/:id/assessments",
+		}
+		assessmentHandlerInstance.setupRoutes(router)
+	})
+	return assessmentHandlerInstance
+}
+
+func (h *AssessmentHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	graders := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleProfessor)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetPlan)
+	group.POST("", graders, h.CreateComponent)
+	group.PUT("/drop-lowest", graders, h.SetDropLowest)
+	group.PUT("/:componentID", graders, h.UpdateComponent)
+	group.DELETE("/:componentID", graders, h.DeleteComponent)
+	group.PUT("/:componentID/scores", graders, h.RecordScores)
+	group.GET("/scores/:studentID", anyone, h.GetScores)
+}
+
+func (h *AssessmentHandler) GetPlan(c *gin.Context) {
+	plan, err := h.AssessmentUsecase.GetPlan(c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *AssessmentHandler) CreateComponent(c *gin.Context) {
+	var component domain.AssessmentComponent
+	if err := c.ShouldBindJSON(&component); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AssessmentUsecase.CreateComponent(principalFromContext(c), c.Param("id"), &component); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, component)
+}
+
+func (h *AssessmentHandler) UpdateComponent(c *gin.Context) {
+	var component domain.AssessmentComponent
+	if err := c.ShouldBindJSON(&component); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	componentID, err := strconv.Atoi(c.Param("componentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	component.ID = componentID
+
+	if err := h.AssessmentUsecase.UpdateComponent(principalFromContext(c), c.Param("id"), &component); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, component)
+}
+
+func (h *AssessmentHandler) DeleteComponent(c *gin.Context) {
+	if err := h.AssessmentUsecase.DeleteComponent(principalFromContext(c), c.Param("id"), c.Param("componentID")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Assessment component deleted successfully"})
+}
+
+func (h *AssessmentHandler) SetDropLowest(c *gin.Context) {
+	var rule domain.DropLowestRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AssessmentUsecase.SetDropLowest(principalFromContext(c), c.Param("id"), &rule); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *AssessmentHandler) RecordScores(c *gin.Context) {
+	var entry domain.ScoreEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grades, err := h.AssessmentUsecase.RecordScores(principalFromContext(c), c.Param("id"), c.Param("componentID"), &entry)
+	if err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, grades)
+}
+
+func (h *AssessmentHandler) GetScores(c *gin.Context) {
+	scores, err := h.AssessmentUsecase.GetScores(principalFromContext(c), c.Param("id"), c.Param("studentID"), c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(scores) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No scores found for this student"})
+		return
+	}
+
+	c.JSON(http.StatusOK, scores)
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceHandler struct {
+	AttendanceUsecase usecase.IAttendanceUsecase
+	path              string
+}
+
+var (
+	attendanceHandlerInstance *AttendanceHandler
+	attendanceHandlerOnce     sync.Once
+)
+
+func NewAttendanceHandler(attendanceUsecase usecase.IAttendanceUsecase, router *gin.Engine) *AttendanceHandler {
+	attendanceHandlerOnce.Do(func() {
+		attendanceHandlerInstance = &AttendanceHandler{
+			AttendanceUsecase: attendanceUsecase,
+			path:              "/courses/:id",
+		}
+		attendanceHandlerInstance.setupRoutes(router)
+	})
+	return attendanceHandlerInstance
+}
+
+func (h *AttendanceHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	staff := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor)
+	graders := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleProfessor)
+
+	group := router.Group(h.path)
+
+	group.GET("/sessions", anyone, h.GetSessions)
+	group.POST("/sessions", graders, h.CreateSession)
+	group.PUT("/sessions/:sessionID", graders, h.UpdateSession)
+	group.DELETE("/sessions/:sessionID", graders, h.DeleteSession)
+	group.GET("/sessions/:sessionID/attendance", staff, h.GetRecords)
+	group.PUT("/sessions/:sessionID/attendance", graders, h.RecordAttendance)
+	group.GET("/attendance", staff, h.GetCourseReport)
+}
+
+func (h *AttendanceHandler) GetSessions(c *gin.Context) {
+	sessions, err := h.AttendanceUsecase.GetSessions(c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(sessions) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No sessions found for this course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (h *AttendanceHandler) CreateSession(c *gin.Context) {
+	var session domain.ClassSession
+	if err := c.ShouldBindJSON(&session); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AttendanceUsecase.CreateSession(principalFromContext(c), c.Param("id"), &session); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, session)
+}
+
+func (h *AttendanceHandler) UpdateSession(c *gin.Context) {
+	var session domain.ClassSession
+	if err := c.ShouldBindJSON(&session); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session.ID = sessionID
+
+	if err := h.AttendanceUsecase.UpdateSession(principalFromContext(c), c.Param("id"), &session); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, session)
+}
+
+func (h *AttendanceHandler) DeleteSession(c *gin.Context) {
+	if err := h.AttendanceUsecase.DeleteSession(principalFromContext(c), c.Param("id"), c.Param("sessionID")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session deleted successfully"})
+}
+
+func (h *AttendanceHandler) GetRecords(c *gin.Context) {
+	records, err := h.AttendanceUsecase.GetRecords(principalFromContext(c), c.Param("id"), c.Param("sessionID"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(records) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No attendance recorded for this session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
+	var entry domain.AttendanceEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := h.AttendanceUsecase.RecordAttendance(principalFromContext(c), c.Param("id"), c.Param("sessionID"), &entry)
+	if err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+func (h *AttendanceHandler) GetCourseReport(c *gin.Context) {
+	report, err := h.AttendanceUsecase.GetCourseReport(principalFromContext(c), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	AuditUsecase usecase.IAuditUsecase
+	path         string
+}
+
+var (
+	auditHandlerInstance *AuditHandler
+	auditHandlerOnce     sync.Once
+)
+
+func NewAuditHandler(auditUsecase usecase.IAuditUsecase, router *gin.Engine) *AuditHandler {
+	auditHandlerOnce.Do(func() {
+		auditHandlerInstance = &AuditHandler{
+			AuditUsecase: auditUsecase,
+			path:         "/audit",
+		}
+		auditHandlerInstance.setupRoutes(router)
+	})
+	return auditHandlerInstance
+}
+
+func (h *AuditHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+	group.Use(middlewares.RequireRoles(domain.RoleAdmin))
+
+	group.GET("", h.Find)
+}
+
+// Find lists audit entries, newest first. It can be filtered with the query
+// parameters entity, entity_id, actor_id, action, from and to (RFC 3339), and
+// paged with limit and offset.
+func (h *AuditHandler) Find(c *gin.Context) {
+	filter := &domain.AuditFilter{
+		Entity: c.Query("entity"),
+		Action: c.Query("action"),
+	}
+
+	var err error
+	for param, target := range map[string]*int{
+		"entity_id": &filter.EntityID,
+		"actor_id":  &filter.ActorUserID,
+		"limit":     &filter.Limit,
+		"offset":    &filter.Offset,
+	} {
+		if value := c.Query(param); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a number"})
+				return
+			}
+		}
+	}
+	for param, target := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	} {
+		if value := c.Query(param); value != "" {
+			if *target, err = time.Parse(time.RFC3339, value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be an RFC 3339 date"})
+				return
+			}
+		}
+	}
+
+	entries, err := h.AuditUsecase.Find(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(entries) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No audit entries found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
 	"net/http"
 	"strconv"
 	"sync"
@@ -11,8 +12,10 @@ import (
 )
 
 type CoursesHandler struct {
-	CoursesUsecase usecase.ICourseUsecase
-	path           string
+	CoursesUsecase      usecase.ICourseUsecase
+	SectionUsecase      usecase.ISectionUsecase
+	PrerequisiteUsecase usecase.IPrerequisiteUsecase
+	path                string
 }
 
 var (
@@ -20,11 +23,13 @@ var (
 	coursesHandlerOnce     sync.Once
 )
 
-func NewCourseHandler(coursesUsecase usecase.ICourseUsecase, router *gin.Engine) *CoursesHandler {
+func NewCourseHandler(coursesUsecase usecase.ICourseUsecase, sectionUsecase usecase.ISectionUsecase, prerequisiteUsecase usecase.IPrerequisiteUsecase, router *gin.Engine) *CoursesHandler {
 	coursesHandlerOnce.Do(func() {
 		coursesHandlerInstance = &CoursesHandler{
-			CoursesUsecase: coursesUsecase,
-			path:           "/courses",
+			CoursesUsecase:      coursesUsecase,
+			SectionUsecase:      sectionUsecase,
+			PrerequisiteUsecase: prerequisiteUsecase,
+			path:                "/courses",
 		}
 		coursesHandlerInstance.setupRoutes(router)
 	})
@@ -32,11 +37,25 @@ func NewCourseHandler(coursesUsecase usecase.ICourseUsecase, router *gin.Engine)
 }
 
 func (h *CoursesHandler) setupRoutes(router *gin.Engine) {
-	router.GET(h.path, h.GetAll)
-	router.GET(h.path+"/:id", h.GetByID)
-	router.POST(h.path+"/create", h.Create)
-	router.PUT(h.path+"/update/:id", h.Update)
-	router.DELETE(h.path+"/delete/:id", h.Delete)
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	staff := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+	group.GET("/:id/sections", anyone, h.GetSections)
+	group.POST("/:id/sections", registrar, h.CreateSection)
+	group.PUT("/:id/sections/:sectionID", registrar, h.UpdateSection)
+	group.DELETE("/:id/sections/:sectionID", registrar, h.DeleteSection)
+	group.GET("/:id/sections/:sectionID/waitlist", staff, h.GetWaitlist)
+	group.GET("/:id/prerequisites", anyone, h.GetPrerequisites)
+	group.POST("/:id/prerequisites", registrar, h.AddPrerequisite)
+	group.DELETE("/:id/prerequisites/:prerequisiteID", registrar, h.RemovePrerequisite)
 }
 
 func (h *CoursesHandler) GetAll(c *gin.Context) {
@@ -64,7 +83,7 @@ func (h *CoursesHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.CoursesUsecase.Create(&courses); err != nil {
+	if err := h.CoursesUsecase.Create(principalFromContext(c), &courses); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -94,8 +113,8 @@ func (h *CoursesHandler) Update(c *gin.Context) {
 
 	courses.ID = idInt
 
-	if err := h.CoursesUsecase.Update(&courses); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.CoursesUsecase.Update(principalFromContext(c), &courses); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, courses)
@@ -103,9 +122,134 @@ func (h *CoursesHandler) Update(c *gin.Context) {
 
 func (h *CoursesHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.CoursesUsecase.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.CoursesUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Course deleted successfully"})
 }
+
+func (h *CoursesHandler) GetSections(c *gin.Context) {
+	id := c.Param("id")
+	sections, err := h.SectionUsecase.GetByCourseID(id, c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(sections) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No sections found for this course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sections)
+}
+
+func (h *CoursesHandler) CreateSection(c *gin.Context) {
+	id := c.Param("id")
+
+	var section domain.Section
+	if err := c.ShouldBindJSON(&section); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.SectionUsecase.Create(principalFromContext(c), id, &section); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, section)
+}
+
+func (h *CoursesHandler) UpdateSection(c *gin.Context) {
+	id := c.Param("id")
+
+	var section domain.Section
+	if err := c.ShouldBindJSON(&section); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sectionID, err := strconv.Atoi(c.Param("sectionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	section.ID = sectionID
+
+	if err := h.SectionUsecase.Update(principalFromContext(c), id, &section); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, section)
+}
+
+func (h *CoursesHandler) DeleteSection(c *gin.Context) {
+	id := c.Param("id")
+	sectionID := c.Param("sectionID")
+	if err := h.SectionUsecase.Delete(principalFromContext(c), id, sectionID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Section deleted successfully"})
+}
+
+func (h *CoursesHandler) GetWaitlist(c *gin.Context) {
+	id := c.Param("id")
+	sectionID := c.Param("sectionID")
+	waitlist, err := h.SectionUsecase.GetWaitlist(id, sectionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(waitlist) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "The waitlist is empty"})
+		return
+	}
+
+	c.JSON(http.StatusOK, waitlist)
+}
+
+func (h *CoursesHandler) GetPrerequisites(c *gin.Context) {
+	id := c.Param("id")
+	prerequisites, err := h.PrerequisiteUsecase.GetByCourseID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(prerequisites) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "This course has no prerequisites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, prerequisites)
+}
+
+func (h *CoursesHandler) AddPrerequisite(c *gin.Context) {
+	id := c.Param("id")
+
+	var prerequisite domain.Prerequisite
+	if err := c.ShouldBindJSON(&prerequisite); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.PrerequisiteUsecase.Add(id, &prerequisite); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, prerequisite)
+}
+
+func (h *CoursesHandler) RemovePrerequisite(c *gin.Context) {
+	id := c.Param("id")
+	prerequisiteID := c.Param("prerequisiteID")
+	if err := h.PrerequisiteUsecase.Remove(id, prerequisiteID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Prerequisite removed successfully"})
+}
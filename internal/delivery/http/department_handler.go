@@ -0,0 +1,117 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DepartmentHandler struct {
+	DepartmentUsecase usecase.IDepartmentUsecase
+	path              string
+}
+
+var (
+	departmentHandlerInstance *DepartmentHandler
+	departmentHandlerOnce     sync.Once
+)
+
+func NewDepartmentHandler(departmentUsecase usecase.IDepartmentUsecase, router *gin.Engine) *DepartmentHandler {
+	departmentHandlerOnce.Do(func() {
+		departmentHandlerInstance = &DepartmentHandler{
+			DepartmentUsecase: departmentUsecase,
+			path:              "/departments",
+		}
+		departmentHandlerInstance.setupRoutes(router)
+	})
+	return departmentHandlerInstance
+}
+
+func (h *DepartmentHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+}
+
+func (h *DepartmentHandler) GetAll(c *gin.Context) {
+	departments, err := h.DepartmentUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(departments) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No departments found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, departments)
+}
+
+func (h *DepartmentHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	department, err := h.DepartmentUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, department)
+}
+
+func (h *DepartmentHandler) Create(c *gin.Context) {
+	var department domain.Department
+	if err := c.ShouldBindJSON(&department); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.DepartmentUsecase.Create(principalFromContext(c), &department); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, department)
+}
+
+func (h *DepartmentHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var department domain.Department
+	if err := c.ShouldBindJSON(&department); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	department.ID = idInt
+
+	if err := h.DepartmentUsecase.Update(principalFromContext(c), &department); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, department)
+}
+
+func (h *DepartmentHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.DepartmentUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Department deleted successfully"})
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/usecase"
 	"golang-technical-test/middlewares"
@@ -33,22 +34,24 @@ func NewEnrollmentHandler(enrollmentUsecase usecase.IEnrollmentUsecase, router *
 }
 
 func (h *EnrollmentHandler) setupRoutes(router *gin.Engine) {
-	JWTGroup := router.Group("/private")
-	JWTGroup.Use(middlewares.JWTAuthMiddleware())
-
-	JWTGroup.GET(h.path, h.GetAll)
-	JWTGroup.GET(h.path+"/:id", h.GetByID)
-	JWTGroup.POST(h.path+"/create", h.Create)
-	JWTGroup.PUT(h.path+"/update/:id", h.Update)
-	JWTGroup.DELETE(h.path+"/delete/:id", h.Delete)
-	JWTGroup.GET(h.path+"/student/:studentID", h.GetByStudentID)
-	JWTGroup.GET(h.path+"/course/:courseID", h.GetByCourseID)
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group("/private")
+
+	group.GET(h.path, anyone, h.GetAll)
+	group.GET(h.path+"/:id", anyone, h.GetByID)
+	group.POST(h.path+"/create", registrar, h.Create)
+	group.PUT(h.path+"/update/:id", registrar, h.Update)
+	group.DELETE(h.path+"/delete/:id", registrar, h.Delete)
+	group.GET(h.path+"/student/:studentID", anyone, h.GetByStudentID)
+	group.GET(h.path+"/course/:courseID", anyone, h.GetByCourseID)
 }
 
 func (h *EnrollmentHandler) GetAll(c *gin.Context) {
-	enrollments, err := h.EnrollmentUsecase.GetAll()
+	enrollments, err := h.EnrollmentUsecase.GetAll(principalFromContext(c), c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -62,9 +65,9 @@ func (h *EnrollmentHandler) GetAll(c *gin.Context) {
 
 func (h *EnrollmentHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
-	enrollment, err := h.EnrollmentUsecase.GetByID(id)
+	enrollment, err := h.EnrollmentUsecase.GetByID(principalFromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, enrollment)
@@ -78,9 +81,9 @@ func (h *EnrollmentHandler) Create(c *gin.Context) {
 		return
 	}
 
-	err = h.EnrollmentUsecase.Create(enrollment)
+	err = h.EnrollmentUsecase.Create(principalFromContext(c), enrollment)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), enrollmentErrorBody(err))
 		return
 	}
 
@@ -111,9 +114,9 @@ func (h *EnrollmentHandler) Update(c *gin.Context) {
 
 	enrollment.ID = idInt
 
-	err = h.EnrollmentUsecase.Update(enrollment)
+	err = h.EnrollmentUsecase.Update(principalFromContext(c), enrollment)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), enrollmentErrorBody(err))
 		return
 	}
 
@@ -122,9 +125,9 @@ func (h *EnrollmentHandler) Update(c *gin.Context) {
 
 func (h *EnrollmentHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	err := h.EnrollmentUsecase.Delete(id)
+	err := h.EnrollmentUsecase.Delete(principalFromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -133,9 +136,9 @@ func (h *EnrollmentHandler) Delete(c *gin.Context) {
 
 func (h *EnrollmentHandler) GetByStudentID(c *gin.Context) {
 	studentID := c.Param("studentID")
-	enrollments, err := h.EnrollmentUsecase.GetByStudentID(studentID)
+	enrollments, err := h.EnrollmentUsecase.GetByStudentID(principalFromContext(c), studentID, c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -149,9 +152,9 @@ func (h *EnrollmentHandler) GetByStudentID(c *gin.Context) {
 
 func (h *EnrollmentHandler) GetByCourseID(c *gin.Context) {
 	courseID := c.Param("courseID")
-	enrollments, err := h.EnrollmentUsecase.GetByCourseID(courseID)
+	enrollments, err := h.EnrollmentUsecase.GetByCourseID(principalFromContext(c), courseID, c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -162,3 +165,18 @@ func (h *EnrollmentHandler) GetByCourseID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Enrollments fetched successfully", "data": enrollments})
 }
+
+// enrollmentErrorBody adds the list of unmet prerequisites to the error
+// response when that is why the enrollment was refused.
+func enrollmentErrorBody(err error) gin.H {
+	body := gin.H{"error": err.Error()}
+	var unmet *domain.UnmetPrerequisitesError
+	if errors.As(err, &unmet) {
+		body["unmet_prerequisites"] = unmet.Prerequisites
+	}
+	var conflict *domain.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		body["schedule_conflicts"] = conflict.Conflicts
+	}
+	return body
+}
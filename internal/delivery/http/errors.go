@@ -0,0 +1,60 @@
+package http
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// errorStatus maps the domain errors returned by the usecases to an HTTP
+// status code. Anything unknown is reported as an internal error.
+func errorStatus(err error) int {
+	var unmetPrerequisites *domain.UnmetPrerequisitesError
+	var scheduleConflict *domain.ScheduleConflictError
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrSectionFull),
+		errors.Is(err, domain.ErrRoomBooked),
+		errors.Is(err, domain.ErrStatusChange),
+		errors.As(err, &scheduleConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrProfessorNotAssigned),
+		errors.Is(err, domain.ErrNoActiveTerm),
+		errors.Is(err, domain.ErrInvalidTermDates),
+		errors.Is(err, domain.ErrSectionRequired),
+		errors.Is(err, domain.ErrSectionMismatch),
+		errors.Is(err, domain.ErrSectionChange),
+		errors.Is(err, domain.ErrPrerequisiteCycle),
+		errors.Is(err, domain.ErrInvalidGradingScale),
+		errors.Is(err, domain.ErrGradeOutOfRange),
+		errors.Is(err, domain.ErrAssessmentWeights),
+		errors.Is(err, domain.ErrScoreOutOfRange),
+		errors.Is(err, domain.ErrNotEnrolled),
+		errors.Is(err, domain.ErrInvalidMeetingTime),
+		errors.Is(err, domain.ErrNoProgram),
+		errors.Is(err, domain.ErrStudentNotActive),
+		errors.Is(err, domain.ErrGradesFrozen),
+		errors.As(err, &unmetPrerequisites):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// validationErrorStatus is errorStatus for usecases that validate the
+// request body: validation errors are reported as 400.
+func validationErrorStatus(err error) int {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return http.StatusBadRequest
+	}
+	return errorStatus(err)
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
 	"net/http"
 	"strconv"
 	"sync"
@@ -32,20 +33,26 @@ func NewGradeHandler(gradeUsecase usecase.IGradeUsecase, router *gin.Engine) *Gr
 }
 
 func (h *GradeHandler) setupRoutes(router *gin.Engine) {
-	router.GET(h.path, h.GetAll)
-	router.GET(h.path+"/:id", h.GetByID)
-	router.POST(h.path+"/create", h.Create)
-	router.PUT(h.path+"/update/:id", h.Update)
-	router.DELETE(h.path+"/delete/:id", h.Delete)
-	router.GET(h.path+"/student/:studentID", h.GetByStudentID)
-	router.GET(h.path+"/course/:courseID", h.GetByCourseID)
-	router.GET(h.path+"/professor/:professorID", h.GetByProfessorID)
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	graders := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleProfessor)
+	admin := middlewares.RequireRoles(domain.RoleAdmin)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", graders, h.Create)
+	group.PUT("/update/:id", graders, h.Update)
+	group.DELETE("/delete/:id", admin, h.Delete)
+	group.GET("/student/:studentID", anyone, h.GetByStudentID)
+	group.GET("/course/:courseID", anyone, h.GetByCourseID)
+	group.GET("/professor/:professorID", anyone, h.GetByProfessorID)
 }
 
 func (h *GradeHandler) GetAll(c *gin.Context) {
-	grades, err := h.GradeUsecase.GetAll()
+	grades, err := h.GradeUsecase.GetAll(principalFromContext(c), c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -58,9 +65,9 @@ func (h *GradeHandler) GetAll(c *gin.Context) {
 
 func (h *GradeHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
-	grade, err := h.GradeUsecase.GetByID(id)
+	grade, err := h.GradeUsecase.GetByID(principalFromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, grade)
@@ -73,9 +80,9 @@ func (h *GradeHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	err = h.GradeUsecase.Create(&grade)
+	err = h.GradeUsecase.Create(principalFromContext(c), &grade)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, grade)
@@ -105,9 +112,9 @@ func (h *GradeHandler) Update(c *gin.Context) {
 
 	grade.ID = idInt
 
-	err = h.GradeUsecase.Update(&grade)
+	err = h.GradeUsecase.Update(principalFromContext(c), &grade)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, grade)
@@ -115,9 +122,9 @@ func (h *GradeHandler) Update(c *gin.Context) {
 
 func (h *GradeHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	err := h.GradeUsecase.Delete(id)
+	err := h.GradeUsecase.Delete(principalFromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Grade deleted successfully"})
@@ -125,9 +132,9 @@ func (h *GradeHandler) Delete(c *gin.Context) {
 
 func (h *GradeHandler) GetByStudentID(c *gin.Context) {
 	studentID := c.Param("studentID")
-	grades, err := h.GradeUsecase.GetByStudentID(studentID)
+	grades, err := h.GradeUsecase.GetByStudentID(principalFromContext(c), studentID, c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -141,9 +148,9 @@ func (h *GradeHandler) GetByStudentID(c *gin.Context) {
 
 func (h *GradeHandler) GetByCourseID(c *gin.Context) {
 	courseID := c.Param("courseID")
-	grades, err := h.GradeUsecase.GetByCourseID(courseID)
+	grades, err := h.GradeUsecase.GetByCourseID(principalFromContext(c), courseID, c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -157,9 +164,9 @@ func (h *GradeHandler) GetByCourseID(c *gin.Context) {
 
 func (h *GradeHandler) GetByProfessorID(c *gin.Context) {
 	professorID := c.Param("professorID")
-	grades, err := h.GradeUsecase.GetByProfessorID(professorID)
+	grades, err := h.GradeUsecase.GetByProfessorID(principalFromContext(c), professorID, c.Query("term_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
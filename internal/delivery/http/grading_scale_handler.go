@@ -0,0 +1,117 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GradingScaleHandler struct {
+	GradingScaleUsecase usecase.IGradingScaleUsecase
+	path                string
+}
+
+var (
+	gradingScaleHandlerInstance *GradingScaleHandler
+	gradingScaleHandlerOnce     sync.Once
+)
+
+func NewGradingScaleHandler(gradingScaleUsecase usecase.IGradingScaleUsecase, router *gin.Engine) *GradingScaleHandler {
+	gradingScaleHandlerOnce.Do(func() {
+		gradingScaleHandlerInstance = &GradingScaleHandler{
+			GradingScaleUsecase: gradingScaleUsecase,
+			path:                "/grading-scales",
+		}
+		gradingScaleHandlerInstance.setupRoutes(router)
+	})
+	return gradingScaleHandlerInstance
+}
+
+func (h *GradingScaleHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+}
+
+func (h *GradingScaleHandler) GetAll(c *gin.Context) {
+	scales, err := h.GradingScaleUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(scales) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No grading scales found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, scales)
+}
+
+func (h *GradingScaleHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	scale, err := h.GradingScaleUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scale)
+}
+
+func (h *GradingScaleHandler) Create(c *gin.Context) {
+	var scale domain.GradingScale
+	if err := c.ShouldBindJSON(&scale); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.GradingScaleUsecase.Create(principalFromContext(c), &scale); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, scale)
+}
+
+func (h *GradingScaleHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var scale domain.GradingScale
+	if err := c.ShouldBindJSON(&scale); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scale.ID = idInt
+
+	if err := h.GradingScaleUsecase.Update(principalFromContext(c), &scale); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scale)
+}
+
+func (h *GradingScaleHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.GradingScaleUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Grading scale deleted successfully"})
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	path string
+}
+
+var (
+	healthHandlerInstance *HealthHandler
+	healthHandlerOnce     sync.Once
+)
+
+func NewHealthHandler(router *gin.Engine) *HealthHandler {
+	healthHandlerOnce.Do(func() {
+		healthHandlerInstance = &HealthHandler{
+			path: "/health",
+		}
+		healthHandlerInstance.setupRoutes(router)
+	})
+	return healthHandlerInstance
+}
+
+func (h *HealthHandler) setupRoutes(router *gin.Engine) {
+	router.GET(h.path, h.Get)
+}
+
+func (h *HealthHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
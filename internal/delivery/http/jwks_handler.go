@@ -0,0 +1,36 @@
+package http
+
+import (
+	"golang-technical-test/middlewares"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	path string
+}
+
+var (
+	jwksHandlerInstance *JWKSHandler
+	jwksHandlerOnce     sync.Once
+)
+
+func NewJWKSHandler(router *gin.Engine) *JWKSHandler {
+	jwksHandlerOnce.Do(func() {
+		jwksHandlerInstance = &JWKSHandler{
+			path: "/.well-known/jwks.json",
+		}
+		jwksHandlerInstance.setupRoutes(router)
+	})
+	return jwksHandlerInstance
+}
+
+func (h *JWKSHandler) setupRoutes(router *gin.Engine) {
+	router.GET(h.path, h.Get)
+}
+
+func (h *JWKSHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, middlewares.PublicKeys())
+}
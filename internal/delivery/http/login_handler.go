@@ -1,15 +1,26 @@
 package http
 
 import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
 	"golang-technical-test/middlewares"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type LoginHandler struct {
-	path string
+	UserUsecase            usecase.IUserUsecase
+	RefreshTokenUsecase    usecase.IRefreshTokenUsecase
+	TokenRevocationUsecase usecase.ITokenRevocationUsecase
+	LoginThrottleUsecase   usecase.ILoginThrottleUsecase
+	MFAUsecase             usecase.IMFAUsecase
+	path                   string
 }
 
 var (
@@ -17,10 +28,15 @@ var (
 	loginHandlerOnce     sync.Once
 )
 
-func NewLoginHandler(router *gin.Engine) *LoginHandler {
+func NewLoginHandler(userUsecase usecase.IUserUsecase, refreshTokenUsecase usecase.IRefreshTokenUsecase, tokenRevocationUsecase usecase.ITokenRevocationUsecase, loginThrottleUsecase usecase.ILoginThrottleUsecase, mfaUsecase usecase.IMFAUsecase, router *gin.Engine) *LoginHandler {
 	loginHandlerOnce.Do(func() {
 		loginHandlerInstance = &LoginHandler{
-			path: "/login",
+			UserUsecase:            userUsecase,
+			RefreshTokenUsecase:    refreshTokenUsecase,
+			TokenRevocationUsecase: tokenRevocationUsecase,
+			LoginThrottleUsecase:   loginThrottleUsecase,
+			MFAUsecase:             mfaUsecase,
+			path:                   "/login",
 		}
 		loginHandlerInstance.setupRoutes(router)
 	})
@@ -29,6 +45,9 @@ func NewLoginHandler(router *gin.Engine) *LoginHandler {
 
 func (h *LoginHandler) setupRoutes(router *gin.Engine) {
 	router.POST(h.path, h.Login)
+	router.POST(h.path+"/mfa", h.LoginMFA)
+	router.POST("/token/refresh", h.Refresh)
+	router.POST("/logout", h.Logout)
 }
 
 func (h *LoginHandler) Login(c *gin.Context) {
@@ -42,15 +61,225 @@ func (h *LoginHandler) Login(c *gin.Context) {
 		return
 	}
 
-	if login.Username == "test" && login.Password == "test" {
-		token, err := middlewares.CreateToken(login.Username)
+	if !h.checkThrottle(c, login.Username) {
+		return
+	}
+
+	user, err := h.UserUsecase.Authenticate(login.Username, login.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCredentials):
+			if err := h.LoginThrottleUsecase.RegisterFailure(login.Username, c.ClientIP()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		case errors.Is(err, domain.ErrUserDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": "User is disabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if requireMFA(c, h.MFAUsecase, user) {
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+// requireMFA answers a user with two-factor authentication with a pending
+// token, to send along with their code to /login/mfa, and a staff user
+// without it with a token that only reaches the enrollment routes. It
+// reports whether it answered the request.
+func requireMFA(c *gin.Context, mfaUsecase usecase.IMFAUsecase, user *domain.User) bool {
+	step, err := mfaUsecase.LoginStep(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return true
+	}
+
+	switch step {
+	case domain.MFAStepVerify:
+		mfaToken, err := middlewares.CreateMFAPendingToken(user.ID, user.Username)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+			return true
+		}
+		c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": mfaToken})
+	case domain.MFAStepEnroll:
+		enrollmentToken, err := middlewares.CreateMFAEnrollmentToken(user.ID, user.Username, user.Role)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+			return true
+		}
+		c.JSON(http.StatusOK, gin.H{"mfa_enrollment_required": true, "mfa_enrollment_token": enrollmentToken})
+	default:
+		return false
+	}
+	return true
+}
+
+// LoginMFA is the second login step of users with two-factor authentication.
+// It takes the pending token returned by Login and a code from the
+// authenticator app or a recovery code.
+func (h *LoginHandler) LoginMFA(c *gin.Context) {
+	var request struct {
+		MFAToken string `json:"mfa_token" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := middlewares.ValidateMFAPendingToken(request.MFAToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		return
+	}
+
+	if !h.checkThrottle(c, claims.Username) {
+		return
+	}
+
+	user, err := h.MFAUsecase.Verify(claims.UserID, request.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidMFACode):
+			if err := h.LoginThrottleUsecase.RegisterFailure(claims.Username, c.ClientIP()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid code"})
+		case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrInvalidToken):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		case errors.Is(err, domain.ErrUserDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": "User is disabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if err := h.TokenRevocationUsecase.RevokeToken(claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+func (h *LoginHandler) Refresh(c *gin.Context) {
+	var request struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, refreshToken, err := h.RefreshTokenUsecase.Rotate(request.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithTokens(c, user, refreshToken)
+}
+
+func (h *LoginHandler) Logout(c *gin.Context) {
+	var request struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.RefreshTokenUsecase.Revoke(request.RefreshToken); err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-		c.JSON(http.StatusOK, gin.H{"token": token})
-	} else {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	// The access token sent along, if still valid, is revoked as well.
+	if tokenString, ok := middlewares.ExtractBearerToken(c.GetHeader("Authorization")); ok {
+		if claims, err := middlewares.ValidateToken(tokenString); err == nil {
+			if err := h.TokenRevocationUsecase.RevokeToken(claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
 	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// checkThrottle answers 429 and returns false when the caller has to wait
+// before trying to log in again.
+func (h *LoginHandler) checkThrottle(c *gin.Context, username string) bool {
+	retryAfter, err := h.LoginThrottleUsecase.Check(username, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed login attempts, try again later"})
+		return false
+	}
+
+	return true
+}
+
+// completeLogin clears the failed attempts of the user and starts a new session.
+func (h *LoginHandler) completeLogin(c *gin.Context, user *domain.User) {
+	if err := h.LoginThrottleUsecase.RegisterSuccess(user.Username); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	refreshToken, err := h.RefreshTokenUsecase.Issue(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		return
+	}
+
+	respondWithTokens(c, user, refreshToken)
+}
+
+// respondWithTokens answers a successful login with a new access token for
+// the user and the session's refresh token.
+func respondWithTokens(c *gin.Context, user *domain.User, refreshToken string) {
+	claims := &middlewares.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	}
+	if user.StudentID != nil {
+		claims.StudentID = *user.StudentID
+	}
+	if user.ProfessorID != nil {
+		claims.ProfessorID = *user.ProfessorID
+	}
+
+	token, err := middlewares.CreateToken(claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token, "refresh_token": refreshToken})
 }
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MeetingHandler struct {
+	MeetingUsecase usecase.IMeetingUsecase
+	path           string
+}
+
+var (
+	meetingHandlerInstance *MeetingHandler
+	meetingHandlerOnce     sync.Once
+)
+
+func NewMeetingHandler(meetingUsecase usecase.IMeetingUsecase, router *gin.Engine) *MeetingHandler {
+	meetingHandlerOnce.Do(func() {
+		meetingHandlerInstance = &MeetingHandler{
+			MeetingUsecase: meetingUsecase,
+			path:           "/courses/:id/meetings",
+		}
+		meetingHandlerInstance.setupRoutes(router)
+	})
+	return meetingHandlerInstance
+}
+
+func (h *MeetingHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.POST("", registrar, h.Create)
+	group.PUT("/:meetingID", registrar, h.Update)
+	group.DELETE("/:meetingID", registrar, h.Delete)
+}
+
+func (h *MeetingHandler) GetAll(c *gin.Context) {
+	meetings, err := h.MeetingUsecase.GetByCourseID(c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(meetings) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No meetings found for this course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, meetings)
+}
+
+func (h *MeetingHandler) Create(c *gin.Context) {
+	var meeting domain.Meeting
+	if err := c.ShouldBindJSON(&meeting); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.MeetingUsecase.Create(principalFromContext(c), c.Param("id"), &meeting); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, meeting)
+}
+
+func (h *MeetingHandler) Update(c *gin.Context) {
+	var meeting domain.Meeting
+	if err := c.ShouldBindJSON(&meeting); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meetingID, err := strconv.Atoi(c.Param("meetingID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meeting.ID = meetingID
+
+	if err := h.MeetingUsecase.Update(principalFromContext(c), c.Param("id"), &meeting); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, meeting)
+}
+
+func (h *MeetingHandler) Delete(c *gin.Context) {
+	if err := h.MeetingUsecase.Delete(principalFromContext(c), c.Param("id"), c.Param("meetingID")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Meeting deleted successfully"})
+}
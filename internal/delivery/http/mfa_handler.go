@@ -0,0 +1,121 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAHandler struct {
+	MFAUsecase usecase.IMFAUsecase
+	path       string
+}
+
+var (
+	mfaHandlerInstance *MFAHandler
+	mfaHandlerOnce     sync.Once
+)
+
+func NewMFAHandler(mfaUsecase usecase.IMFAUsecase, router *gin.Engine) *MFAHandler {
+	mfaHandlerOnce.Do(func() {
+		mfaHandlerInstance = &MFAHandler{
+			MFAUsecase: mfaUsecase,
+			path:       "/mfa",
+		}
+		mfaHandlerInstance.setupRoutes(router)
+	})
+	return mfaHandlerInstance
+}
+
+func (h *MFAHandler) setupRoutes(router *gin.Engine) {
+	staff := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor)
+	admin := middlewares.RequireRoles(domain.RoleAdmin)
+
+	group := router.Group(h.path)
+
+	group.POST("/totp/enroll", staff, h.Enroll)
+	group.POST("/totp/activate", staff, h.Activate)
+	// Staff who log in without two-factor authentication get a token that
+	// only reaches these two routes.
+	middlewares.AcceptPurpose(middlewares.PurposeMFAEnrollment, "POST "+h.path+"/totp/enroll", "POST "+h.path+"/totp/activate")
+	group.POST("/totp/disable", staff, h.Disable)
+	group.POST("/recovery-codes", staff, h.RegenerateRecoveryCodes)
+	group.PUT("/reset/:id", admin, h.Reset)
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Enroll returns a new TOTP secret and its otpauth URI for the authenticator app.
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	secret, uri, err := h.MFAUsecase.Enroll(principalFromContext(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"secret": secret, "otpauth_uri": uri})
+}
+
+// Activate returns the recovery codes in clear text. It is the only time they are shown.
+func (h *MFAHandler) Activate(c *gin.Context) {
+	var request mfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.MFAUsecase.Activate(principalFromContext(c), request.Code)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var request mfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.MFAUsecase.Disable(principalFromContext(c), request.Code); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled successfully"})
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var request mfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.MFAUsecase.RegenerateRecoveryCodes(principalFromContext(c), request.Code)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *MFAHandler) Reset(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.MFAUsecase.Reset(id); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset successfully"})
+}
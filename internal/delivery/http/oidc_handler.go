@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	OIDCUsecase         usecase.IOIDCUsecase
+	RefreshTokenUsecase usecase.IRefreshTokenUsecase
+	MFAUsecase          usecase.IMFAUsecase
+	path                string
+}
+
+var (
+	oidcHandlerInstance *OIDCHandler
+	oidcHandlerOnce     sync.Once
+)
+
+func NewOIDCHandler(oidcUsecase usecase.IOIDCUsecase, refreshTokenUsecase usecase.IRefreshTokenUsecase, mfaUsecase usecase.IMFAUsecase, router *gin.Engine) *OIDCHandler {
+	oidcHandlerOnce.Do(func() {
+		oidcHandlerInstance = &OIDCHandler{
+			OIDCUsecase:         oidcUsecase,
+			RefreshTokenUsecase: refreshTokenUsecase,
+			MFAUsecase:          mfaUsecase,
+			path:                "/login/oidc",
+		}
+		oidcHandlerInstance.setupRoutes(router)
+	})
+	return oidcHandlerInstance
+}
+
+func (h *OIDCHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+
+	group.GET("", h.Login)
+	group.GET("/callback", h.Callback)
+}
+
+// Login redirects the user to the identity provider.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authURL, err := h.OIDCUsecase.BeginLogin(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Error contacting the identity provider"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback is where the identity provider sends the user back. It answers
+// like /login, with the project's own tokens, or with a pending token when
+// the user has two-factor authentication.
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": providerErr, "error_description": c.Query("error_description")})
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "state and code are required"})
+		return
+	}
+
+	user, err := h.OIDCUsecase.CompleteLogin(c.Request.Context(), state, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidToken):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusForbidden, gin.H{"error": "No local account is linked to this identity"})
+		case errors.Is(err, domain.ErrUserDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": "User is disabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if requireMFA(c, h.MFAUsecase, user) {
+		return
+	}
+
+	refreshToken, err := h.RefreshTokenUsecase.Issue(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		return
+	}
+
+	respondWithTokens(c, user, refreshToken)
+}
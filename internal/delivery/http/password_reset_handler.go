@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+	PasswordResetUsecase usecase.IPasswordResetUsecase
+	path                 string
+}
+
+var (
+	passwordResetHandlerInstance *PasswordResetHandler
+	passwordResetHandlerOnce     sync.Once
+)
+
+func NewPasswordResetHandler(passwordResetUsecase usecase.IPasswordResetUsecase, router *gin.Engine) *PasswordResetHandler {
+	passwordResetHandlerOnce.Do(func() {
+		passwordResetHandlerInstance = &PasswordResetHandler{
+			PasswordResetUsecase: passwordResetUsecase,
+			path:                 "/password/reset",
+		}
+		passwordResetHandlerInstance.setupRoutes(router)
+	})
+	return passwordResetHandlerInstance
+}
+
+func (h *PasswordResetHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+
+	group.POST("/request", h.Request)
+	group.POST("/confirm", h.Confirm)
+}
+
+// Request answers the same way whether or not the email has an account,
+// and whether or not the request is throttled.
+func (h *PasswordResetHandler) Request(c *gin.Context) {
+	var request struct {
+		Email string `json:"email" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.PasswordResetUsecase.RequestReset(request.Email, c.ClientIP())
+	c.JSON(http.StatusOK, gin.H{"message": "If the email belongs to an account, a reset link has been sent"})
+}
+
+func (h *PasswordResetHandler) Confirm(c *gin.Context) {
+	var request struct {
+		Token    string `json:"token" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.PasswordResetUsecase.ConfirmReset(request.Token, request.Password); err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Reset token is invalid or has expired"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
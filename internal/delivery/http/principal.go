@@ -0,0 +1,26 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalFromContext builds the caller identity passed to the usecases
+// from the claims JWTAuthMiddleware stored in the context.
+func principalFromContext(c *gin.Context) *domain.Principal {
+	claims, ok := middlewares.GetClaims(c)
+	if !ok {
+		return &domain.Principal{}
+	}
+
+	return &domain.Principal{
+		UserID:      claims.UserID,
+		Username:    claims.Username,
+		Role:        claims.Role,
+		StudentID:   claims.StudentID,
+		ProfessorID: claims.ProfessorID,
+		Scopes:      claims.Scopes,
+	}
+}
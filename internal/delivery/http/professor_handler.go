@@ -3,6 +3,7 @@ package http
 import (
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
 	"net/http"
 	"strconv"
 	"sync"
@@ -11,8 +12,9 @@ import (
 )
 
 type ProfessorHandler struct {
-	ProfessorUsecase usecase.IProfessorUsecase
-	path             string
+	ProfessorUsecase          usecase.IProfessorUsecase
+	TeachingAssignmentUsecase usecase.ITeachingAssignmentUsecase
+	path                      string
 }
 
 var (
@@ -20,11 +22,12 @@ var (
 	professorHandlerOnce     sync.Once
 )
 
-func NewProfessorHandler(professorUsecase usecase.IProfessorUsecase, router *gin.Engine) *ProfessorHandler {
+func NewProfessorHandler(professorUsecase usecase.IProfessorUsecase, teachingAssignmentUsecase usecase.ITeachingAssignmentUsecase, router *gin.Engine) *ProfessorHandler {
 	professorHandlerOnce.Do(func() {
 		professorHandlerInstance = &ProfessorHandler{
-			ProfessorUsecase: professorUsecase,
-			path:             "/professor",
+			ProfessorUsecase:          professorUsecase,
+			TeachingAssignmentUsecase: teachingAssignmentUsecase,
+			path:                      "/professor",
 		}
 		professorHandlerInstance.setupRoutes(router)
 	})
@@ -32,11 +35,19 @@ func NewProfessorHandler(professorUsecase usecase.IProfessorUsecase, router *gin
 }
 
 func (h *ProfessorHandler) setupRoutes(router *gin.Engine) {
-	router.GET(h.path, h.GetAll)
-	router.GET(h.path+"/:id", h.GetByID)
-	router.POST(h.path+"/create", h.Create)
-	router.PUT(h.path+"/update/:id", h.Update)
-	router.DELETE(h.path+"/delete/:id", h.Delete)
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+	group.GET("/:id/courses", anyone, h.GetCourses)
+	group.POST("/:id/courses", registrar, h.AssignCourse)
+	group.DELETE("/:id/courses/:courseID", registrar, h.UnassignCourse)
 }
 
 func (h *ProfessorHandler) GetAll(c *gin.Context) {
@@ -76,7 +87,7 @@ func (h *ProfessorHandler) Create(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.ProfessorUsecase.Create(&professor); err != nil {
+	if err := h.ProfessorUsecase.Create(principalFromContext(c), &professor); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -106,8 +117,8 @@ func (h *ProfessorHandler) Update(c *gin.Context) {
 
 	professor.ID = idInt
 
-	if err := h.ProfessorUsecase.Update(&professor); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.ProfessorUsecase.Update(principalFromContext(c), &professor); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, professor)
@@ -120,9 +131,54 @@ func (h *ProfessorHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.ProfessorUsecase.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.ProfessorUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Professor deleted successfully"})
 }
+
+func (h *ProfessorHandler) GetCourses(c *gin.Context) {
+	id := c.Param("id")
+	courses, err := h.TeachingAssignmentUsecase.GetCoursesByProfessorID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(courses) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No courses assigned to this professor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, courses)
+}
+
+func (h *ProfessorHandler) AssignCourse(c *gin.Context) {
+	id := c.Param("id")
+
+	var request struct {
+		CourseID int `json:"course_id"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, err := h.TeachingAssignmentUsecase.Assign(id, request.CourseID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, assignment)
+}
+
+func (h *ProfessorHandler) UnassignCourse(c *gin.Context) {
+	id := c.Param("id")
+	courseID := c.Param("courseID")
+	if err := h.TeachingAssignmentUsecase.Unassign(id, courseID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Course unassigned successfully"})
+}
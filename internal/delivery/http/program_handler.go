@@ -0,0 +1,117 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProgramHandler struct {
+	ProgramUsecase usecase.IProgramUsecase
+	path           string
+}
+
+var (
+	programHandlerInstance *ProgramHandler
+	programHandlerOnce     sync.Once
+)
+
+func NewProgramHandler(programUsecase usecase.IProgramUsecase, router *gin.Engine) *ProgramHandler {
+	programHandlerOnce.Do(func() {
+		programHandlerInstance = &ProgramHandler{
+			ProgramUsecase: programUsecase,
+			path:           "/programs",
+		}
+		programHandlerInstance.setupRoutes(router)
+	})
+	return programHandlerInstance
+}
+
+func (h *ProgramHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+}
+
+func (h *ProgramHandler) GetAll(c *gin.Context) {
+	programs, err := h.ProgramUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(programs) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No programs found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, programs)
+}
+
+func (h *ProgramHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	program, err := h.ProgramUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, program)
+}
+
+func (h *ProgramHandler) Create(c *gin.Context) {
+	var program domain.Program
+	if err := c.ShouldBindJSON(&program); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.ProgramUsecase.Create(principalFromContext(c), &program); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, program)
+}
+
+func (h *ProgramHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var program domain.Program
+	if err := c.ShouldBindJSON(&program); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	program.ID = idInt
+
+	if err := h.ProgramUsecase.Update(principalFromContext(c), &program); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, program)
+}
+
+func (h *ProgramHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.ProgramUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Program deleted successfully"})
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomHandler struct {
+	RoomUsecase usecase.IRoomUsecase
+	path        string
+}
+
+var (
+	roomHandlerInstance *RoomHandler
+	roomHandlerOnce     sync.Once
+)
+
+func NewRoomHandler(roomUsecase usecase.IRoomUsecase, router *gin.Engine) *RoomHandler {
+	roomHandlerOnce.Do(func() {
+		roomHandlerInstance = &RoomHandler{
+			RoomUsecase: roomUsecase,
+			path:        "/rooms",
+		}
+		roomHandlerInstance.setupRoutes(router)
+	})
+	return roomHandlerInstance
+}
+
+func (h *RoomHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/available", anyone, h.GetAvailable)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+}
+
+func (h *RoomHandler) GetAll(c *gin.Context) {
+	rooms, err := h.RoomUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(rooms) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No rooms found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+// GetAvailable lists the rooms free on day from start to end. It can be
+// narrowed with min_capacity, features (comma separated) and term_id.
+func (h *RoomHandler) GetAvailable(c *gin.Context) {
+	filter := &domain.RoomAvailabilityFilter{
+		Day:       c.Query("day"),
+		StartTime: c.Query("start"),
+		EndTime:   c.Query("end"),
+	}
+	if features := c.Query("features"); features != "" {
+		filter.Features = strings.Split(features, ",")
+	}
+
+	var err error
+	for param, target := range map[string]*int{
+		"min_capacity": &filter.MinCapacity,
+		"term_id":      &filter.TermID,
+	} {
+		if value := c.Query(param); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a number"})
+				return
+			}
+		}
+	}
+
+	rooms, err := h.RoomUsecase.GetAvailable(filter)
+	if err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rooms)
+}
+
+func (h *RoomHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	room, err := h.RoomUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+func (h *RoomHandler) Create(c *gin.Context) {
+	var room domain.Room
+	if err := c.ShouldBindJSON(&room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.RoomUsecase.Create(principalFromContext(c), &room); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, room)
+}
+
+func (h *RoomHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var room domain.Room
+	if err := c.ShouldBindJSON(&room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	room.ID = idInt
+
+	if err := h.RoomUsecase.Update(principalFromContext(c), &room); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+func (h *RoomHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.RoomUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Room deleted successfully"})
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
 	"net/http"
 	"strconv"
 	"sync"
@@ -11,8 +12,12 @@ import (
 )
 
 type StudentHandler struct {
-	StudentUsecase usecase.IStudentUsecase
-	path           string
+	StudentUsecase    usecase.IStudentUsecase
+	GPAUsecase        usecase.IGPAUsecase
+	AttendanceUsecase usecase.IAttendanceUsecase
+	MeetingUsecase    usecase.IMeetingUsecase
+	ProgramUsecase    usecase.IProgramUsecase
+	path              string
 }
 
 var (
@@ -20,11 +25,15 @@ var (
 	studentHandlerOnce     sync.Once
 )
 
-func NewStudentHandler(studentUsecase usecase.IStudentUsecase, router *gin.Engine) *StudentHandler {
+func NewStudentHandler(studentUsecase usecase.IStudentUsecase, gpaUsecase usecase.IGPAUsecase, attendanceUsecase usecase.IAttendanceUsecase, meetingUsecase usecase.IMeetingUsecase, programUsecase usecase.IProgramUsecase, router *gin.Engine) *StudentHandler {
 	studentHandlerOnce.Do(func() {
 		studentHandlerInstance = &StudentHandler{
-			StudentUsecase: studentUsecase,
-			path:           "/students",
+			StudentUsecase:    studentUsecase,
+			GPAUsecase:        gpaUsecase,
+			AttendanceUsecase: attendanceUsecase,
+			MeetingUsecase:    meetingUsecase,
+			ProgramUsecase:    programUsecase,
+			path:              "/students",
 		}
 		studentHandlerInstance.setupRoutes(router)
 	})
@@ -32,11 +41,28 @@ func NewStudentHandler(studentUsecase usecase.IStudentUsecase, router *gin.Engin
 }
 
 func (h *StudentHandler) setupRoutes(router *gin.Engine) {
-	router.GET(h.path, h.GetAll)
-	router.GET(h.path+"/:id", h.GetByID)
-	router.POST(h.path+"/create", h.Create)
-	router.PUT(h.path+"/update/:id", h.Update)
-	router.DELETE(h.path+"/delete/:id", h.Delete)
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	staff := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", staff, h.GetAll)
+	group.GET("/:id", staff, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+	group.GET("/:id/gpa", anyone, h.GetGPA)
+	group.GET("/:id/attendance", anyone, h.GetAttendance)
+	group.GET("/:id/schedule", anyone, h.GetSchedule)
+	group.PUT("/:id/program", registrar, h.AssignProgram)
+	group.GET("/:id/degree-audit", anyone, h.GetDegreeAudit)
+	group.GET("/:id/status-history", anyone, h.GetStatusHistory)
+	group.POST("/:id/activate", registrar, h.Activate)
+	group.POST("/:id/leave", registrar, h.Leave)
+	group.POST("/:id/suspend", registrar, h.Suspend)
+	group.POST("/:id/graduate", registrar, h.Graduate)
+	group.POST("/:id/withdraw", registrar, h.Withdraw)
 }
 
 func (h *StudentHandler) GetAll(c *gin.Context) {
@@ -64,7 +90,7 @@ func (h *StudentHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.StudentUsecase.Create(&student); err != nil {
+	if err := h.StudentUsecase.Create(principalFromContext(c), &student); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -94,8 +120,8 @@ func (h *StudentHandler) Update(c *gin.Context) {
 
 	student.ID = idInt
 
-	if err := h.StudentUsecase.Update(&student); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.StudentUsecase.Update(principalFromContext(c), &student); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, student)
@@ -103,9 +129,118 @@ func (h *StudentHandler) Update(c *gin.Context) {
 
 func (h *StudentHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.StudentUsecase.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.StudentUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
+
+func (h *StudentHandler) GetGPA(c *gin.Context) {
+	id := c.Param("id")
+	gpa, err := h.GPAUsecase.GetByStudentID(principalFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gpa)
+}
+
+func (h *StudentHandler) GetAttendance(c *gin.Context) {
+	id := c.Param("id")
+	report, err := h.AttendanceUsecase.GetStudentReport(principalFromContext(c), id, c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *StudentHandler) GetSchedule(c *gin.Context) {
+	id := c.Param("id")
+	schedule, err := h.MeetingUsecase.GetSchedule(principalFromContext(c), id, c.Query("term_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *StudentHandler) AssignProgram(c *gin.Context) {
+	id := c.Param("id")
+
+	var request struct {
+		ProgramID *int `json:"program_id"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	student, err := h.ProgramUsecase.AssignStudent(principalFromContext(c), id, request.ProgramID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, student)
+}
+
+func (h *StudentHandler) GetDegreeAudit(c *gin.Context) {
+	id := c.Param("id")
+	audit, err := h.ProgramUsecase.GetDegreeAudit(principalFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, audit)
+}
+
+func (h *StudentHandler) GetStatusHistory(c *gin.Context) {
+	id := c.Param("id")
+	changes, err := h.StudentUsecase.GetStatusHistory(principalFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+func (h *StudentHandler) Activate(c *gin.Context) {
+	h.changeStatus(c, domain.StudentStatusActive)
+}
+
+func (h *StudentHandler) Leave(c *gin.Context) {
+	h.changeStatus(c, domain.StudentStatusOnLeave)
+}
+
+func (h *StudentHandler) Suspend(c *gin.Context) {
+	h.changeStatus(c, domain.StudentStatusSuspended)
+}
+
+func (h *StudentHandler) Graduate(c *gin.Context) {
+	h.changeStatus(c, domain.StudentStatusGraduated)
+}
+
+func (h *StudentHandler) Withdraw(c *gin.Context) {
+	h.changeStatus(c, domain.StudentStatusWithdrawn)
+}
+
+// changeStatus moves the student to status, with the reason given in the
+// request body.
+func (h *StudentHandler) changeStatus(c *gin.Context, status string) {
+	id := c.Param("id")
+
+	var change domain.StudentStatusChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	change.ToStatus = status
+
+	student, err := h.StudentUsecase.ChangeStatus(principalFromContext(c), id, &change)
+	if err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, student)
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TermHandler struct {
+	TermUsecase usecase.ITermUsecase
+	path        string
+}
+
+var (
+	termHandlerInstance *TermHandler
+	termHandlerOnce     sync.Once
+)
+
+func NewTermHandler(termUsecase usecase.ITermUsecase, router *gin.Engine) *TermHandler {
+	termHandlerOnce.Do(func() {
+		termHandlerInstance = &TermHandler{
+			TermUsecase: termUsecase,
+			path:        "/terms",
+		}
+		termHandlerInstance.setupRoutes(router)
+	})
+	return termHandlerInstance
+}
+
+func (h *TermHandler) setupRoutes(router *gin.Engine) {
+	anyone := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar, domain.RoleProfessor, domain.RoleStudent)
+	registrar := middlewares.RequireRoles(domain.RoleAdmin, domain.RoleRegistrar)
+
+	group := router.Group(h.path)
+
+	group.GET("", anyone, h.GetAll)
+	group.GET("/active", anyone, h.GetActive)
+	group.GET("/:id", anyone, h.GetByID)
+	group.POST("/create", registrar, h.Create)
+	group.PUT("/update/:id", registrar, h.Update)
+	group.DELETE("/delete/:id", registrar, h.Delete)
+}
+
+func (h *TermHandler) GetAll(c *gin.Context) {
+	terms, err := h.TermUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(terms) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No terms found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, terms)
+}
+
+func (h *TermHandler) GetActive(c *gin.Context) {
+	term, err := h.TermUsecase.GetActive()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+func (h *TermHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	term, err := h.TermUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+func (h *TermHandler) Create(c *gin.Context) {
+	var term domain.Term
+	if err := c.ShouldBindJSON(&term); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.TermUsecase.Create(principalFromContext(c), &term); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, term)
+}
+
+func (h *TermHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var term domain.Term
+	if err := c.ShouldBindJSON(&term); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	term.ID = idInt
+
+	if err := h.TermUsecase.Update(principalFromContext(c), &term); err != nil {
+		c.JSON(validationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+func (h *TermHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.TermUsecase.Delete(principalFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Term deleted successfully"})
+}
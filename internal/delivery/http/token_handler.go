@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TokenHandler struct {
+	TokenRevocationUsecase usecase.ITokenRevocationUsecase
+	path                   string
+}
+
+var (
+	tokenHandlerInstance *TokenHandler
+	tokenHandlerOnce     sync.Once
+)
+
+func NewTokenHandler(tokenRevocationUsecase usecase.ITokenRevocationUsecase, router *gin.Engine) *TokenHandler {
+	tokenHandlerOnce.Do(func() {
+		tokenHandlerInstance = &TokenHandler{
+			TokenRevocationUsecase: tokenRevocationUsecase,
+			path:                   "/tokens",
+		}
+		tokenHandlerInstance.setupRoutes(router)
+	})
+	return tokenHandlerInstance
+}
+
+func (h *TokenHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+	group.Use(middlewares.RequireRoles(domain.RoleAdmin))
+
+	group.POST("/revoke", h.Revoke)
+	group.POST("/revoke/user/:id", h.RevokeUser)
+}
+
+// Revoke denies a single access token, given either the token itself or its JWT ID.
+func (h *TokenHandler) Revoke(c *gin.Context) {
+	var request struct {
+		Token string `json:"token"`
+		JTI   string `json:"jti"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jti := request.JTI
+	// Without the token we can't know when it expires, but it can't outlive a fresh one.
+	expiresAt := time.Now().Add(middlewares.AccessTokenTTL)
+	if request.Token != "" {
+		claims, err := middlewares.ValidateToken(request.Token)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Token is invalid or already expired"})
+			return
+		}
+		jti = claims.Id
+		expiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+
+	if err := h.TokenRevocationUsecase.RevokeToken(jti, expiresAt); err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Either token or jti is required"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
+
+func (h *TokenHandler) RevokeUser(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.TokenRevocationUsecase.RevokeUser(id); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User tokens revoked successfully"})
+}
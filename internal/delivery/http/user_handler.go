@@ -0,0 +1,175 @@
+package http
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/usecase"
+	"golang-technical-test/middlewares"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	UserUsecase usecase.IUserUsecase
+	path        string
+}
+
+var (
+	userHandlerInstance *UserHandler
+	userHandlerOnce     sync.Once
+)
+
+func NewUserHandler(userUsecase usecase.IUserUsecase, router *gin.Engine) *UserHandler {
+	userHandlerOnce.Do(func() {
+		userHandlerInstance = &UserHandler{
+			UserUsecase: userUsecase,
+			path:        "/users",
+		}
+		userHandlerInstance.setupRoutes(router)
+	})
+	return userHandlerInstance
+}
+
+func (h *UserHandler) setupRoutes(router *gin.Engine) {
+	group := router.Group(h.path)
+	group.Use(middlewares.RequireRoles(domain.RoleAdmin))
+
+	group.GET("", h.GetAll)
+	group.GET("/:id", h.GetByID)
+	group.POST("/create", h.Create)
+	group.PUT("/role/:id", h.ChangeRole)
+	group.PUT("/email/:id", h.ChangeEmail)
+	group.PUT("/disable/:id", h.Disable)
+	group.PUT("/enable/:id", h.Enable)
+	group.PUT("/reset-password/:id", h.ResetPassword)
+}
+
+func (h *UserHandler) GetAll(c *gin.Context) {
+	users, err := h.UserUsecase.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(users) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "No users found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *UserHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	user, err := h.UserUsecase.GetByID(id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) Create(c *gin.Context) {
+	var request struct {
+		Username    string `json:"username"`
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		Role        string `json:"role"`
+		StudentID   *int   `json:"student_id"`
+		ProfessorID *int   `json:"professor_id"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := &domain.User{Username: request.Username, Email: request.Email, Role: request.Role, StudentID: request.StudentID, ProfessorID: request.ProfessorID}
+	if err := h.UserUsecase.Create(user, request.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	id := c.Param("id")
+
+	var request struct {
+		Role        string `json:"role"`
+		StudentID   *int   `json:"student_id"`
+		ProfessorID *int   `json:"professor_id"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserUsecase.ChangeRole(id, request.Role, request.StudentID, request.ProfessorID); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User role updated successfully"})
+}
+
+func (h *UserHandler) ChangeEmail(c *gin.Context) {
+	id := c.Param("id")
+
+	var request struct {
+		Email string `json:"email"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserUsecase.ChangeEmail(id, request.Email); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User email updated successfully"})
+}
+
+func (h *UserHandler) Disable(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.UserUsecase.Disable(id); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User disabled successfully"})
+}
+
+func (h *UserHandler) Enable(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.UserUsecase.Enable(id); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User enabled successfully"})
+}
+
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	id := c.Param("id")
+
+	var request struct {
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserUsecase.ResetPassword(id, request.Password); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+func userErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
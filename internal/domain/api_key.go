@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"golang-technical-test/utils"
+	"time"
+)
+
+// APIKey lets a service call the API without a user's credentials. Its
+// scopes are the roles it is granted; only a hash of the key is stored.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name" validate:"required"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes" validate:"required,min=1,dive,oneof=admin registrar professor"`
+	CreatedBy  int        `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Revoked    bool       `json:"revoked"`
+}
+
+func (v *APIKey) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
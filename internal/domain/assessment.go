@@ -0,0 +1,64 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// AssessmentComponent is one graded piece of a course, such as an exam or a
+// homework. Scores go from 0 to MaxScore. The weights of a course's
+// components must sum to 100 before its final grades can be computed.
+type AssessmentComponent struct {
+	ID       int     `json:"id"`
+	CourseID int     `json:"course_id"`
+	Name     string  `json:"name" validate:"required"`
+	Category string  `json:"category" validate:"required"`
+	Weight   float64 `json:"weight" validate:"gt=0,lte=100"`
+	MaxScore float64 `json:"max_score" validate:"gt=0"`
+}
+
+func (v *AssessmentComponent) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// AssessmentPlan is how a course is graded. DropLowest gives, per category,
+// how many of the student's lowest scores are left out; their weight goes
+// to the rest of the category.
+type AssessmentPlan struct {
+	CourseID    int                    `json:"course_id"`
+	Components  []*AssessmentComponent `json:"components"`
+	DropLowest  map[string]int         `json:"drop_lowest"`
+	TotalWeight float64                `json:"total_weight"`
+}
+
+// DropLowestRule sets how many of the lowest scores of a category are left
+// out of the final grade.
+type DropLowestRule struct {
+	Category   string `json:"category" validate:"required"`
+	DropLowest int    `json:"drop_lowest" validate:"min=0"`
+}
+
+func (v *DropLowestRule) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// AssessmentScore is a student's score in a component in a term.
+type AssessmentScore struct {
+	ComponentID int     `json:"component_id"`
+	StudentID   int     `json:"student_id" validate:"required"`
+	TermID      int     `json:"term_id"`
+	Score       float64 `json:"score" validate:"min=0"`
+}
+
+// ScoreEntry records the scores of several students in one component. The
+// final grades it recomputes are given by ProfessorID, which defaults to
+// the professor linked to the caller's account.
+type ScoreEntry struct {
+	TermID      int               `json:"term_id"`
+	ProfessorID int               `json:"professor_id" validate:"required"`
+	Scores      []AssessmentScore `json:"scores" validate:"required,min=1,dive"`
+}
+
+func (v *ScoreEntry) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
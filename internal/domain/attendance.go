@@ -0,0 +1,70 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Attendance statuses. Late counts as attended; excused absences are left
+// out of the attendance rate.
+const (
+	AttendancePresent = "present"
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+)
+
+// ClassSession is one meeting of a course on a date. When SectionID is set
+// only the students enrolled in that section attend it.
+type ClassSession struct {
+	ID        int    `json:"id"`
+	CourseID  int    `json:"course_id"`
+	TermID    int    `json:"term_id"`
+	SectionID *int   `json:"section_id,omitempty"`
+	Date      string `json:"date" validate:"required,datetime=2006-01-02"`
+	Topic     string `json:"topic"`
+}
+
+func (v *ClassSession) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// AttendanceRecord is whether a student attended a session.
+type AttendanceRecord struct {
+	SessionID int    `json:"session_id"`
+	StudentID int    `json:"student_id" validate:"required"`
+	Status    string `json:"status" validate:"required,oneof=present absent late excused"`
+}
+
+// AttendanceEntry records the attendance of several students to a session.
+type AttendanceEntry struct {
+	Records []AttendanceRecord `json:"records" validate:"required,min=1,dive"`
+}
+
+func (v *AttendanceEntry) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// AttendanceRate sums up a student's attendance to a course in a term.
+// Rate is the percentage of sessions attended, present or late, out of
+// those not excused. Sessions held without marking the student are
+// counted in Absent and also in Unmarked. AtRisk is set when Rate is below
+// the configured threshold.
+type AttendanceRate struct {
+	StudentID int     `json:"student_id"`
+	CourseID  int     `json:"course_id"`
+	TermID    int     `json:"term_id"`
+	Sessions  int     `json:"sessions"`
+	Present   int     `json:"present"`
+	Absent    int     `json:"absent"`
+	Late      int     `json:"late"`
+	Excused   int     `json:"excused"`
+	Unmarked  int     `json:"unmarked"`
+	Rate      float64 `json:"rate"`
+	AtRisk    bool    `json:"at_risk"`
+}
+
+// AttendanceReport lists the attendance rates of a course or a student.
+type AttendanceReport struct {
+	Threshold float64           `json:"threshold"`
+	Rates     []*AttendanceRate `json:"rates"`
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Entities recorded in the audit log.
+const (
+	AuditEntityStudent    = "student"
+	AuditEntityCourse     = "course"
+	AuditEntityProfessor  = "professor"
+	AuditEntityGrade      = "grade"
+	AuditEntityEnrollment = "enrollment"
+	AuditEntityTerm       = "term"
+	AuditEntitySection    = "section"
+	AuditEntityScale      = "grading_scale"
+	AuditEntityComponent  = "assessment_component"
+	AuditEntityCategory   = "assessment_category"
+	AuditEntitySession    = "class_session"
+	AuditEntityAttendance = "attendance"
+	AuditEntityMeeting    = "meeting"
+	AuditEntityRoom       = "room"
+	AuditEntityDepartment = "department"
+	AuditEntityProgram    = "program"
+)
+
+// AuditEntry records one change to an entity and who made it. Before is
+// empty for creations and After is empty for deletions.
+type AuditEntry struct {
+	ID            int             `json:"id"`
+	ActorUserID   *int            `json:"actor_user_id,omitempty"`
+	ActorUsername string          `json:"actor_username"`
+	ActorRole     string          `json:"actor_role,omitempty"`
+	Action        string          `json:"action"`
+	Entity        string          `json:"entity"`
+	EntityID      int             `json:"entity_id"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// AuditFilter selects audit entries. Zero fields don't filter.
+type AuditFilter struct {
+	Entity      string
+	EntityID    int
+	ActorUserID int
+	Action      string
+	From        time.Time
+	To          time.Time
+	Limit       int
+	Offset      int
+}
@@ -6,6 +6,9 @@ type Course struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description" validate:"required"`
+	Credits     int    `json:"credits" validate:"min=0"`
+	// GradingScaleID is the scale the course's grades are checked against.
+	GradingScaleID *int `json:"grading_scale_id,omitempty"`
 }
 
 func (v *Course) Validate() error {
@@ -2,10 +2,19 @@ package domain
 
 import "golang-technical-test/utils"
 
+// Enrollment statuses. A waitlisted enrollment holds no seat in its section.
+const (
+	EnrollmentStatusEnrolled   = "enrolled"
+	EnrollmentStatusWaitlisted = "waitlisted"
+)
+
 type Enrollment struct {
-	ID        int `json:"id" validate:"required"`
-	StudentID int `json:"student_id" validate:"required"`
-	CourseID  int `json:"course_id" validate:"required"`
+	ID        int    `json:"id" validate:"required"`
+	StudentID int    `json:"student_id" validate:"required"`
+	CourseID  int    `json:"course_id" validate:"required"`
+	TermID    int    `json:"term_id"`
+	SectionID *int   `json:"section_id,omitempty"`
+	Status    string `json:"status"`
 }
 
 func (v *Enrollment) Validate() error {
@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserDisabled       = errors.New("user is disabled")
+	ErrNotFound           = errors.New("record not found")
+	ErrForbidden          = errors.New("you are not allowed to access this resource")
+	ErrConflict           = errors.New("record already exists")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrTokenReused        = errors.New("refresh token reuse detected")
+	ErrInvalidMFACode     = errors.New("invalid two-factor authentication code")
+
+	ErrProfessorNotAssigned = errors.New("the professor is not assigned to this course")
+	ErrNoActiveTerm         = errors.New("term_id is required when there is no active term")
+	ErrInvalidTermDates     = errors.New("the term must end after it starts")
+	ErrSectionFull          = errors.New("the section is full")
+	ErrSectionRequired      = errors.New("section_id is required: the course has sections in this term")
+	ErrSectionMismatch      = errors.New("the section belongs to another course or term")
+	ErrSectionChange        = errors.New("an enrollment can't move to another section; drop it and enroll again")
+	ErrPrerequisiteCycle    = errors.New("the prerequisite would create a cycle")
+	ErrInvalidGradingScale  = errors.New("the grade ranges must start at the scale's minimum grade and have distinct minimums within the scale")
+	ErrGradeOutOfRange      = errors.New("the grade is outside the course's grading scale")
+	ErrAssessmentWeights    = errors.New("the weights of the course's assessment components must sum to 100")
+	ErrScoreOutOfRange      = errors.New("the score must be between 0 and the component's maximum score")
+	ErrNotEnrolled          = errors.New("the student is not enrolled in the course")
+	ErrInvalidMeetingTime   = errors.New("the meeting must end after it starts")
+	ErrRoomBooked           = errors.New("the room is already booked at that time")
+	ErrNoProgram            = errors.New("the student is not assigned to a program")
+	ErrStudentNotActive     = errors.New("the student is not active")
+	ErrGradesFrozen         = errors.New("the grades of a graduated student can't change")
+	ErrStatusChange         = errors.New("the student can't change to that status")
+)
@@ -0,0 +1,27 @@
+package domain
+
+// Rules for the grade of a course taken more than once.
+const (
+	RetakeRuleLatest  = "latest"
+	RetakeRuleBest    = "best"
+	RetakeRuleAverage = "average"
+)
+
+// GPA is a student's credit-weighted average of grade points, from 0 to
+// MaxGradePoints, overall and per term. Courses without credits don't
+// count.
+type GPA struct {
+	StudentID  int       `json:"student_id"`
+	RetakeRule string    `json:"retake_rule"`
+	Cumulative float64   `json:"cumulative"`
+	Credits    int       `json:"credits"`
+	Terms      []TermGPA `json:"terms"`
+}
+
+// TermGPA is the GPA of the grades of one term.
+type TermGPA struct {
+	TermID   int     `json:"term_id"`
+	TermName string  `json:"term_name"`
+	GPA      float64 `json:"gpa"`
+	Credits  int     `json:"credits"`
+}
@@ -7,7 +7,12 @@ type Grade struct {
 	StudentID   int     `json:"student_id" validate:"required"`
 	CourseID    int     `json:"course_id" validate:"required"`
 	ProfessorID int     `json:"professor_id" validate:"required"`
+	TermID      int     `json:"term_id"`
 	Grade       float64 `json:"grade" validate:"required"`
+	// Letter and Passed come from the course's grading scale and are empty
+	// when the course has none.
+	Letter string `json:"letter,omitempty"`
+	Passed *bool  `json:"passed,omitempty"`
 }
 
 func (v *Grade) Validate() error {
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"golang-technical-test/utils"
+	"math"
+	"sort"
+)
+
+// GradingScale gives meaning to the numeric grades of the courses that use
+// it. Grades must lie between MinGrade and MaxGrade. Each range starts at
+// its MinGrade and ends where the next one starts; the lowest range must
+// start at the scale's MinGrade so every grade falls in one.
+type GradingScale struct {
+	ID       int          `json:"id"`
+	Name     string       `json:"name" validate:"required"`
+	MinGrade float64      `json:"min_grade"`
+	MaxGrade float64      `json:"max_grade" validate:"gtfield=MinGrade"`
+	Ranges   []GradeRange `json:"ranges" validate:"required,min=1,dive"`
+}
+
+// GradeRange maps the grades from MinGrade up to the next range to a letter
+// and says whether they pass. Points, when set, are the grade points the
+// range is worth in the GPA.
+type GradeRange struct {
+	Letter   string   `json:"letter" validate:"required"`
+	MinGrade float64  `json:"min_grade"`
+	Passing  bool     `json:"passing"`
+	Points   *float64 `json:"points,omitempty" validate:"omitempty,min=0,max=4"`
+}
+
+// MaxGradePoints is the top of the grade point scale the GPA is computed on.
+const MaxGradePoints = 4.0
+
+// DefaultScaleMax is the top of the 0 to DefaultScaleMax range the grades of
+// courses without a grading scale are read on.
+const DefaultScaleMax = 100.0
+
+func (v *GradingScale) Validate() error {
+	vali := utils.GetValidator()
+	if err := vali.Struct(v); err != nil {
+		return err
+	}
+
+	sort.Slice(v.Ranges, func(i, j int) bool { return v.Ranges[i].MinGrade < v.Ranges[j].MinGrade })
+	if v.Ranges[0].MinGrade != v.MinGrade {
+		return ErrInvalidGradingScale
+	}
+	for i, r := range v.Ranges {
+		if r.MinGrade > v.MaxGrade || (i > 0 && r.MinGrade == v.Ranges[i-1].MinGrade) {
+			return ErrInvalidGradingScale
+		}
+	}
+	return nil
+}
+
+// Classify returns the range a grade falls in, or ErrGradeOutOfRange when
+// the grade is outside the scale. Ranges must be sorted by MinGrade, as
+// Validate and the repository leave them.
+func (v *GradingScale) Classify(grade float64) (*GradeRange, error) {
+	if grade < v.MinGrade || grade > v.MaxGrade {
+		return nil, ErrGradeOutOfRange
+	}
+	for i := len(v.Ranges) - 1; i >= 0; i-- {
+		if grade >= v.Ranges[i].MinGrade {
+			return &v.Ranges[i], nil
+		}
+	}
+	return nil, ErrGradeOutOfRange
+}
+
+// GradePoints converts a grade to grade points: the Points of its range
+// when set, or else its position between MinGrade and MaxGrade mapped
+// linearly onto 0 to MaxGradePoints. Grades outside the scale, recorded
+// before it changed, are clamped to its bounds.
+func (v *GradingScale) GradePoints(grade float64) float64 {
+	grade = math.Min(math.Max(grade, v.MinGrade), v.MaxGrade)
+	if gradeRange, err := v.Classify(grade); err == nil && gradeRange.Points != nil {
+		return *gradeRange.Points
+	}
+	return linearPoints(grade, v.MinGrade, v.MaxGrade)
+}
+
+// DefaultGradePoints converts the grade of a course without a grading scale
+// to grade points, reading it on 0 to DefaultScaleMax.
+func DefaultGradePoints(grade float64) float64 {
+	return linearPoints(math.Min(math.Max(grade, 0), DefaultScaleMax), 0, DefaultScaleMax)
+}
+
+func linearPoints(grade, min, max float64) float64 {
+	return (grade - min) / (max - min) * MaxGradePoints
+}
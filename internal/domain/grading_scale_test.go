@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func points(p float64) *float64 {
+	return &p
+}
+
+// letterScale is an A-F scale from 0 to 100 with grade points on every
+// range but D.
+func letterScale() *GradingScale {
+	return &GradingScale{
+		Name:     "letters",
+		MinGrade: 0,
+		MaxGrade: 100,
+		Ranges: []GradeRange{
+			{Letter: "F", MinGrade: 0, Points: points(0)},
+			{Letter: "D", MinGrade: 60, Passing: true},
+			{Letter: "C", MinGrade: 70, Passing: true, Points: points(2)},
+			{Letter: "B", MinGrade: 80, Passing: true, Points: points(3)},
+			{Letter: "A", MinGrade: 90, Passing: true, Points: points(4)},
+		},
+	}
+}
+
+func TestGradingScaleClassify(t *testing.T) {
+	tests := []struct {
+		name   string
+		grade  float64
+		letter string
+		err    error
+	}{
+		{"lowest grade", 0, "F", nil},
+		{"just below a range", 59.99, "F", nil},
+		{"start of a range", 60, "D", nil},
+		{"inside a range", 85, "B", nil},
+		{"highest grade", 100, "A", nil},
+		{"below the scale", -1, "", ErrGradeOutOfRange},
+		{"above the scale", 100.5, "", ErrGradeOutOfRange},
+	}
+
+	scale := letterScale()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gradeRange, err := scale.Classify(tt.grade)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Classify(%v) error = %v, want %v", tt.grade, err, tt.err)
+			}
+			if err == nil && gradeRange.Letter != tt.letter {
+				t.Errorf("Classify(%v) = %s, want %s", tt.grade, gradeRange.Letter, tt.letter)
+			}
+		})
+	}
+}
+
+func TestGradingScaleValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(scale *GradingScale)
+		err    error
+	}{
+		{"valid", func(scale *GradingScale) {}, nil},
+		{"unsorted ranges", func(scale *GradingScale) {
+			scale.Ranges[0], scale.Ranges[4] = scale.Ranges[4], scale.Ranges[0]
+		}, nil},
+		{"lowest range above the minimum", func(scale *GradingScale) {
+			scale.Ranges[0].MinGrade = 10
+		}, ErrInvalidGradingScale},
+		{"range above the maximum", func(scale *GradingScale) {
+			scale.Ranges[4].MinGrade = 101
+		}, ErrInvalidGradingScale},
+		{"two ranges starting together", func(scale *GradingScale) {
+			scale.Ranges[2].MinGrade = 60
+		}, ErrInvalidGradingScale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale := letterScale()
+			tt.modify(scale)
+			if err := scale.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	t.Run("maximum not above the minimum", func(t *testing.T) {
+		scale := letterScale()
+		scale.MaxGrade = 0
+		if err := scale.Validate(); err == nil {
+			t.Error("Validate() = nil, want an error")
+		}
+	})
+}
+
+func TestGradingScaleGradePoints(t *testing.T) {
+	fiveScale := &GradingScale{MinGrade: 0, MaxGrade: 5, Ranges: []GradeRange{{Letter: "fail", MinGrade: 0}, {Letter: "pass", MinGrade: 3, Passing: true}}}
+
+	tests := []struct {
+		name  string
+		scale *GradingScale
+		grade float64
+		want  float64
+	}{
+		{"points of the range", letterScale(), 85, 3},
+		{"points of the top range", letterScale(), 100, 4},
+		{"range without points is linear", letterScale(), 65, 2.6},
+		{"scale without points is linear", fiveScale, 2.5, 2},
+		{"bottom of a linear scale", fiveScale, 0, 0},
+		{"top of a linear scale", fiveScale, 5, 4},
+		{"clamped below the scale", fiveScale, -3, 0},
+		{"clamped above the scale", fiveScale, 7, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scale.GradePoints(tt.grade); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GradePoints(%v) = %v, want %v", tt.grade, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultGradePoints(t *testing.T) {
+	tests := []struct {
+		grade float64
+		want  float64
+	}{
+		{0, 0},
+		{50, 2},
+		{87.5, 3.5},
+		{100, 4},
+		{120, 4},
+		{-5, 0},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultGradePoints(tt.grade); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("DefaultGradePoints(%v) = %v, want %v", tt.grade, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// LoginAttempts tracks the recent failed logins for one username or client IP.
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"golang-technical-test/utils"
+	"strings"
+	"time"
+)
+
+// Weekdays in timetable order.
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// Meeting is a weekly class time of a course in a term, from StartTime to
+// EndTime ("15:04") on Day, optionally in a room. A meeting with a
+// SectionID is only for the students of that section; one without is for
+// everyone in the course. Warnings are not stored; they tell the caller
+// about problems that didn't stop the change, such as a room too small.
+type Meeting struct {
+	ID        int      `json:"id"`
+	CourseID  int      `json:"course_id"`
+	TermID    int      `json:"term_id"`
+	SectionID *int     `json:"section_id,omitempty"`
+	Day       string   `json:"day" validate:"required,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	StartTime string   `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime   string   `json:"end_time" validate:"required,datetime=15:04"`
+	RoomID    *int     `json:"room_id,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// Validate also normalizes the times to two-digit hours and checks that
+// the meeting ends after it starts.
+func (v *Meeting) Validate() error {
+	vali := utils.GetValidator()
+	if err := vali.Struct(v); err != nil {
+		return err
+	}
+
+	start, _ := time.Parse("15:04", v.StartTime)
+	end, _ := time.Parse("15:04", v.EndTime)
+	if !end.After(start) {
+		return ErrInvalidMeetingTime
+	}
+	v.StartTime, v.EndTime = start.Format("15:04"), end.Format("15:04")
+	return nil
+}
+
+// Overlaps reports whether both meetings take place at the same time. A
+// meeting that starts when the other ends does not overlap it.
+func (v *Meeting) Overlaps(other *Meeting) bool {
+	return v.Day == other.Day && v.StartTime < other.EndTime && other.StartTime < v.EndTime
+}
+
+func (v *Meeting) String() string {
+	return fmt.Sprintf("course %d on %s %s-%s", v.CourseID, v.Day, v.StartTime, v.EndTime)
+}
+
+// ScheduleEntry is a meeting in a student's timetable.
+type ScheduleEntry struct {
+	*Meeting
+	CourseName string `json:"course_name"`
+	RoomName   string `json:"room_name,omitempty"`
+	Status     string `json:"status"`
+}
+
+// Schedule is a student's weekly timetable in a term.
+type Schedule struct {
+	StudentID int              `json:"student_id"`
+	TermID    int              `json:"term_id"`
+	Meetings  []*ScheduleEntry `json:"meetings"`
+}
+
+// ScheduleConflictError rejects an enrollment whose meetings overlap those
+// of a course the student is already enrolled in. Conflicts lists the
+// student's meetings that overlap.
+type ScheduleConflictError struct {
+	Conflicts []*Meeting
+}
+
+func (e *ScheduleConflictError) Error() string {
+	meetings := make([]string, len(e.Conflicts))
+	for i, m := range e.Conflicts {
+		meetings[i] = m.String()
+	}
+	return "schedule conflict with " + strings.Join(meetings, ", ")
+}
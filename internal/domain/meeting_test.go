@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeetingOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Meeting
+		want bool
+	}{
+		{"same time", Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, true},
+		{"partly", Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:30"}, Meeting{Day: "monday", StartTime: "10:00", EndTime: "11:00"}, true},
+		{"one inside the other", Meeting{Day: "monday", StartTime: "08:00", EndTime: "12:00"}, Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, true},
+		{"back to back", Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, Meeting{Day: "monday", StartTime: "10:00", EndTime: "11:00"}, false},
+		{"apart", Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, Meeting{Day: "monday", StartTime: "14:00", EndTime: "15:00"}, false},
+		{"another day", Meeting{Day: "monday", StartTime: "09:00", EndTime: "10:00"}, Meeting{Day: "tuesday", StartTime: "09:00", EndTime: "10:00"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(&tt.b); got != tt.want {
+				t.Errorf("a.Overlaps(b) = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Overlaps(&tt.a); got != tt.want {
+				t.Errorf("b.Overlaps(a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeetingValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		meeting   Meeting
+		wantStart string
+		wantEnd   string
+		err       error
+	}{
+		{"valid", Meeting{Day: "friday", StartTime: "09:00", EndTime: "10:30"}, "09:00", "10:30", nil},
+		{"one-digit hours", Meeting{Day: "friday", StartTime: "9:00", EndTime: "9:50"}, "09:00", "09:50", nil},
+		{"ends when it starts", Meeting{Day: "friday", StartTime: "09:00", EndTime: "09:00"}, "", "", ErrInvalidMeetingTime},
+		{"ends before it starts", Meeting{Day: "friday", StartTime: "11:00", EndTime: "10:00"}, "", "", ErrInvalidMeetingTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meeting.Validate()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.err)
+			}
+			if err == nil && (tt.meeting.StartTime != tt.wantStart || tt.meeting.EndTime != tt.wantEnd) {
+				t.Errorf("times = %s-%s, want %s-%s", tt.meeting.StartTime, tt.meeting.EndTime, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	invalid := []Meeting{
+		{Day: "someday", StartTime: "09:00", EndTime: "10:00"},
+		{Day: "monday", StartTime: "25:00", EndTime: "26:00"},
+		{Day: "monday", StartTime: "09:00"},
+	}
+	for _, meeting := range invalid {
+		if err := meeting.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", meeting)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// PasswordResetToken lets a user choose a new password without logging in.
+// It is emailed to the user, can be used once and expires after a while.
+type PasswordResetToken struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"golang-technical-test/utils"
+	"strings"
+)
+
+// Prerequisite says that a student must pass PrerequisiteID before enrolling
+// in CourseID. Without MinGrade a grade passes when the course's grading
+// scale says so, or always if the course has no scale.
+type Prerequisite struct {
+	CourseID       int      `json:"course_id"`
+	PrerequisiteID int      `json:"prerequisite_id" validate:"required"`
+	MinGrade       *float64 `json:"min_grade,omitempty"`
+}
+
+func (v *Prerequisite) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// UnmetPrerequisite is a prerequisite the student has not passed. BestGrade
+// is the student's best grade in the course, if any.
+type UnmetPrerequisite struct {
+	CourseID  int      `json:"course_id"`
+	MinGrade  *float64 `json:"min_grade,omitempty"`
+	BestGrade *float64 `json:"best_grade,omitempty"`
+}
+
+// UnmetPrerequisitesError rejects an enrollment and lists what is missing.
+type UnmetPrerequisitesError struct {
+	Prerequisites []UnmetPrerequisite
+}
+
+func (e *UnmetPrerequisitesError) Error() string {
+	courses := make([]string, len(e.Prerequisites))
+	for i, p := range e.Prerequisites {
+		courses[i] = fmt.Sprintf("course %d", p.CourseID)
+		if p.MinGrade != nil {
+			courses[i] += fmt.Sprintf(" (minimum grade %.2f)", *p.MinGrade)
+		}
+	}
+	return "unmet prerequisites: " + strings.Join(courses, ", ")
+}
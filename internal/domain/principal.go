@@ -0,0 +1,39 @@
+package domain
+
+// Principal is the authenticated caller a usecase acts on behalf of.
+type Principal struct {
+	UserID    int
+	Username  string
+	Role      string
+	StudentID int
+	// ProfessorID is the professor record linked to a professor account.
+	ProfessorID int
+	// Scopes are the roles granted to an API key caller.
+	Scopes []string
+}
+
+// HasRole reports whether the caller holds the role, directly or as an API
+// key scope.
+func (p *Principal) HasRole(role string) bool {
+	if p.Role == role {
+		return true
+	}
+	for _, scope := range p.Scopes {
+		if scope == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStaff reports whether the caller holds a staff role, which is not
+// limited to its own records.
+func (p *Principal) IsStaff() bool {
+	return p.HasRole(RoleAdmin) || p.HasRole(RoleRegistrar) || p.HasRole(RoleProfessor)
+}
+
+// IsStudent reports whether the caller is a student, who may only see
+// records about the student linked to the account.
+func (p *Principal) IsStudent() bool {
+	return p.Role == RoleStudent
+}
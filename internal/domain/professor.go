@@ -8,6 +8,7 @@ type Professor struct {
 	LastName       string `json:"last_name" validate:"required"`
 	Email          string `json:"email" validate:"required"`
 	Specialization string `json:"specialization,omitempty"`
+	DepartmentID   *int   `json:"department_id,omitempty"`
 }
 
 func (v *Professor) Validate() error {
@@ -0,0 +1,71 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Department is an academic unit that professors belong to and that
+// offers programs.
+type Department struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required"`
+	Code string `json:"code" validate:"required"`
+}
+
+func (v *Department) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// Course set kinds. Every course of a required set must be passed; an
+// elective set is complete once the passed courses add up to its
+// MinCredits.
+const (
+	CourseSetRequired = "required"
+	CourseSetElective = "elective"
+)
+
+// Program is a degree offered by a department. A student completes it by
+// completing each of its course sets and earning at least MinCredits.
+type Program struct {
+	ID           int         `json:"id"`
+	DepartmentID int         `json:"department_id" validate:"required"`
+	Name         string      `json:"name" validate:"required"`
+	MinCredits   int         `json:"min_credits" validate:"min=0"`
+	CourseSets   []CourseSet `json:"course_sets" validate:"dive"`
+}
+
+// CourseSet is a group of courses of a program.
+type CourseSet struct {
+	Name       string `json:"name" validate:"required"`
+	Kind       string `json:"kind" validate:"required,oneof=required elective"`
+	MinCredits int    `json:"min_credits" validate:"min=0"`
+	CourseIDs  []int  `json:"course_ids" validate:"required,min=1"`
+}
+
+func (v *Program) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// DegreeAudit compares the courses a student passed with a program.
+// EarnedCredits counts every course passed, in the program or not.
+type DegreeAudit struct {
+	StudentID     int               `json:"student_id"`
+	ProgramID     int               `json:"program_id"`
+	ProgramName   string            `json:"program_name"`
+	MinCredits    int               `json:"min_credits"`
+	EarnedCredits int               `json:"earned_credits"`
+	Complete      bool              `json:"complete"`
+	CourseSets    []*CourseSetAudit `json:"course_sets"`
+}
+
+// CourseSetAudit is the progress of a student in a course set. Remaining
+// lists the courses of the set not passed yet.
+type CourseSetAudit struct {
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	MinCredits    int    `json:"min_credits,omitempty"`
+	EarnedCredits int    `json:"earned_credits"`
+	Passed        []int  `json:"passed"`
+	Remaining     []int  `json:"remaining"`
+	Complete      bool   `json:"complete"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// RefreshToken is a long-lived, single-use token exchanged for a new access token.
+// Every token issued by rotating another one shares its FamilyID, so a whole
+// login session can be revoked at once.
+type RefreshToken struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	Revoked   bool       `json:"revoked"`
+}
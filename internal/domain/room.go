@@ -0,0 +1,47 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Room is a classroom that course meetings can be held in. Features lists
+// what it is equipped with, such as "projector" or "lab".
+type Room struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name" validate:"required"`
+	Building string   `json:"building"`
+	Capacity int      `json:"capacity" validate:"required,min=1"`
+	Features []string `json:"features" validate:"dive,required,excludesall=0x2C"`
+}
+
+func (v *Room) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// HasFeatures reports whether the room has every one of the features.
+func (v *Room) HasFeatures(features []string) bool {
+	for _, feature := range features {
+		found := false
+		for _, f := range v.Features {
+			if f == feature {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// RoomAvailabilityFilter looks for rooms free on Day from StartTime to
+// EndTime in a term, with at least MinCapacity seats and every one of
+// Features.
+type RoomAvailabilityFilter struct {
+	TermID      int
+	Day         string
+	StartTime   string
+	EndTime     string
+	MinCapacity int
+	Features    []string
+}
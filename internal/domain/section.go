@@ -0,0 +1,20 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Section is a group of a course offered in a term, with a limited number
+// of seats. When Waitlist is set, students who enroll in a full section are
+// waitlisted instead of refused, and promoted in order as seats free up.
+type Section struct {
+	ID       int    `json:"id"`
+	CourseID int    `json:"course_id"`
+	TermID   int    `json:"term_id"`
+	Name     string `json:"name" validate:"required"`
+	Capacity int    `json:"capacity" validate:"required,min=1"`
+	Waitlist bool   `json:"waitlist"`
+}
+
+func (v *Section) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
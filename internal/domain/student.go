@@ -1,6 +1,40 @@
 package domain
 
-import "golang-technical-test/utils"
+import (
+	"golang-technical-test/utils"
+	"time"
+)
+
+// Student statuses. New students are applicants; only active students can
+// enroll, and the grades of graduated students can't change.
+const (
+	StudentStatusApplicant = "applicant"
+	StudentStatusActive    = "active"
+	StudentStatusOnLeave   = "on_leave"
+	StudentStatusSuspended = "suspended"
+	StudentStatusGraduated = "graduated"
+	StudentStatusWithdrawn = "withdrawn"
+)
+
+// studentTransitions lists the statuses a student can move to from each
+// status. Graduated and withdrawn are final.
+var studentTransitions = map[string][]string{
+	StudentStatusApplicant: {StudentStatusActive, StudentStatusWithdrawn},
+	StudentStatusActive:    {StudentStatusOnLeave, StudentStatusSuspended, StudentStatusGraduated, StudentStatusWithdrawn},
+	StudentStatusOnLeave:   {StudentStatusActive, StudentStatusWithdrawn},
+	StudentStatusSuspended: {StudentStatusActive, StudentStatusWithdrawn},
+}
+
+// CanChangeStatus reports whether a student can move from one status to
+// the other.
+func CanChangeStatus(from, to string) bool {
+	for _, status := range studentTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
 
 type Student struct {
 	ID          int    `json:"id"`
@@ -9,9 +43,31 @@ type Student struct {
 	DateOfBirth string `json:"date_of_birth" validate:"required"`
 	Address     string `json:"address" validate:"required"`
 	Email       string `json:"email" validate:"required"`
+	// ProgramID is set through the student's program endpoint.
+	ProgramID *int `json:"program_id,omitempty"`
+	// Status is set through the student's status endpoints.
+	Status string `json:"status"`
 }
 
 func (v *Student) Validate() error {
 	vali := utils.GetValidator()
 	return vali.Struct(v)
 }
+
+// StudentStatusChange records a change of a student's status, who made it
+// and why.
+type StudentStatusChange struct {
+	ID            int       `json:"id"`
+	StudentID     int       `json:"student_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status" validate:"required,oneof=applicant active on_leave suspended graduated withdrawn"`
+	Reason        string    `json:"reason" validate:"required"`
+	ActorUserID   *int      `json:"actor_user_id,omitempty"`
+	ActorUsername string    `json:"actor_username"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+func (v *StudentStatusChange) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
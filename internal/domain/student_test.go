@@ -0,0 +1,53 @@
+package domain
+
+import "testing"
+
+func TestCanChangeStatus(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StudentStatusApplicant, StudentStatusActive, true},
+		{StudentStatusApplicant, StudentStatusWithdrawn, true},
+		{StudentStatusApplicant, StudentStatusGraduated, false},
+		{StudentStatusActive, StudentStatusOnLeave, true},
+		{StudentStatusActive, StudentStatusSuspended, true},
+		{StudentStatusActive, StudentStatusGraduated, true},
+		{StudentStatusActive, StudentStatusApplicant, false},
+		{StudentStatusActive, StudentStatusActive, false},
+		{StudentStatusOnLeave, StudentStatusActive, true},
+		{StudentStatusOnLeave, StudentStatusGraduated, false},
+		{StudentStatusSuspended, StudentStatusActive, true},
+		{StudentStatusSuspended, StudentStatusOnLeave, false},
+		{StudentStatusGraduated, StudentStatusActive, false},
+		{StudentStatusWithdrawn, StudentStatusActive, false},
+		{"unknown", StudentStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanChangeStatus(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanChangeStatus(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestStudentStatusChangeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  StudentStatusChange
+		wantErr bool
+	}{
+		{"valid", StudentStatusChange{ToStatus: StudentStatusActive, Reason: "admitted"}, false},
+		{"unknown status", StudentStatusChange{ToStatus: "expelled", Reason: "misconduct"}, true},
+		{"missing reason", StudentStatusChange{ToStatus: StudentStatusSuspended}, true},
+		{"missing status", StudentStatusChange{Reason: "admitted"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
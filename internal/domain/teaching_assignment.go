@@ -0,0 +1,16 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// TeachingAssignment records that a professor teaches a course, which is
+// what allows the professor to grade it.
+type TeachingAssignment struct {
+	ID          int `json:"id"`
+	ProfessorID int `json:"professor_id" validate:"required"`
+	CourseID    int `json:"course_id" validate:"required"`
+}
+
+func (v *TeachingAssignment) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Term is an academic period, such as a semester. Enrollments and grades
+// belong to one term. At most one term is active at a time; it is the
+// default for new enrollments and grades.
+type Term struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name" validate:"required"`
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Active    bool   `json:"active"`
+}
+
+func (v *Term) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
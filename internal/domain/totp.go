@@ -0,0 +1,23 @@
+package domain
+
+// TOTPCredential is the authenticator app secret of a user. It only protects
+// logins once Enabled, which happens after the user proves the app is set up
+// by sending a valid code.
+type TOTPCredential struct {
+	UserID  int    `json:"user_id"`
+	Secret  string `json:"-"`
+	Enabled bool   `json:"enabled"`
+	// LastStep is the time step of the last accepted code, so a code can't be replayed.
+	LastStep int64 `json:"-"`
+}
+
+// Second login steps, as returned by MFAUsecase.LoginStep.
+const (
+	// MFAStepNone lets the user in with the password alone.
+	MFAStepNone = ""
+	// MFAStepVerify asks for a code from the authenticator app or a recovery code.
+	MFAStepVerify = "verify"
+	// MFAStepEnroll holds back the session of a staff user until they set up
+	// two-factor authentication, which is mandatory for staff roles.
+	MFAStepEnroll = "enroll"
+)
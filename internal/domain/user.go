@@ -0,0 +1,33 @@
+package domain
+
+import "golang-technical-test/utils"
+
+// Roles a user can hold. They are embedded in the JWT and checked per route.
+const (
+	RoleAdmin     = "admin"
+	RoleRegistrar = "registrar"
+	RoleProfessor = "professor"
+	RoleStudent   = "student"
+)
+
+type User struct {
+	ID           int    `json:"id"`
+	Username     string `json:"username" validate:"required"`
+	Email        string `json:"email,omitempty" validate:"omitempty,email"`
+	PasswordHash string `json:"-"`
+	Role         string `json:"role" validate:"required,oneof=admin registrar professor student"`
+	StudentID    *int   `json:"student_id,omitempty" validate:"required_if=Role student"`
+	ProfessorID  *int   `json:"professor_id,omitempty" validate:"required_if=Role professor"`
+	Disabled     bool   `json:"disabled"`
+}
+
+func (v *User) Validate() error {
+	vali := utils.GetValidator()
+	return vali.Struct(v)
+}
+
+// IsStaff reports whether the user holds a staff role. Staff users must use
+// two-factor authentication.
+func (v *User) IsStaff() bool {
+	return v.Role == RoleAdmin || v.Role == RoleRegistrar || v.Role == RoleProfessor
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// UserIdentity links a local user to an account at an external OpenID
+// Connect provider, identified by the provider's issuer and subject.
+type UserIdentity struct {
+	ID      int    `json:"id"`
+	UserID  int    `json:"user_id"`
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+}
+
+// OIDCLoginState is kept between sending the user to the provider and the
+// provider redirecting back, to check the callback answers our request.
+type OIDCLoginState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
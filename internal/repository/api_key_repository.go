@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type IAPIKeyRepository interface {
+	GetAll() ([]*domain.APIKey, error)
+	GetByID(id int) (*domain.APIKey, error)
+	GetByHash(keyHash string) (*domain.APIKey, error)
+	Create(key *domain.APIKey) error
+	Revoke(id int) error
+	UpdateLastUsed(id int, usedAt time.Time) error
+}
+
+type APIKeyRepository struct {
+	db *database.Database
+}
+
+var (
+	apiKeyRepoOnce     sync.Once
+	apiKeyRepoInstance *APIKeyRepository
+)
+
+func NewAPIKeyRepository(db *database.Database) IAPIKeyRepository {
+	apiKeyRepoOnce.Do(func() {
+		apiKeyRepoInstance = &APIKeyRepository{}
+		apiKeyRepoInstance.db = db
+	})
+	return apiKeyRepoInstance
+}
+
+const apiKeyColumns = "ID, Name, Prefix, KeyHash, Scopes, CreatedBy, CreatedAt, LastUsedAt, Revoked"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var k domain.APIKey
+	var scopes string
+	var createdAt, lastUsedAt mysql.NullTime
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &scopes, &k.CreatedBy, &createdAt, &lastUsedAt, &k.Revoked)
+	if err != nil {
+		return nil, err
+	}
+
+	k.Scopes = strings.Split(scopes, ",")
+	k.CreatedAt = createdAt.Time
+	if lastUsedAt.Valid {
+		k.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &k, nil
+}
+
+func (r *APIKeyRepository) GetAll() ([]*domain.APIKey, error) {
+	rows, err := r.db.Query("SELECT " + apiKeyColumns + " FROM ApiKeys")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) GetByID(id int) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow("SELECT "+apiKeyColumns+" FROM ApiKeys WHERE ID = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetByHash(keyHash string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow("SELECT "+apiKeyColumns+" FROM ApiKeys WHERE KeyHash = ?", keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) Create(key *domain.APIKey) error {
+	result, err := r.db.Exec("INSERT INTO ApiKeys (Name, Prefix, KeyHash, Scopes, CreatedBy, CreatedAt) VALUES (?, ?, ?, ?, ?, ?)", key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, ","), key.CreatedBy, key.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	key.ID = int(keyID)
+
+	return nil
+}
+
+func (r *APIKeyRepository) Revoke(id int) error {
+	_, err := r.db.Exec("UPDATE ApiKeys SET Revoked = TRUE WHERE ID = ?", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) UpdateLastUsed(id int, usedAt time.Time) error {
+	_, err := r.db.Exec("UPDATE ApiKeys SET LastUsedAt = ? WHERE ID = ?", usedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
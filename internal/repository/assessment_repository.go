@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IAssessmentRepository interface {
+	GetComponentsByCourseID(courseID int) ([]*domain.AssessmentComponent, error)
+	GetComponentByID(id int) (*domain.AssessmentComponent, error)
+	CreateComponent(component *domain.AssessmentComponent) error
+	UpdateComponent(component *domain.AssessmentComponent) error
+	DeleteComponent(id int) error
+	GetDropLowest(courseID int) (map[string]int, error)
+	SetDropLowest(courseID int, category string, count int) error
+	SaveScores(scores []domain.AssessmentScore) error
+	GetScoresByCourseID(courseID int) ([]*domain.AssessmentScore, error)
+	GetScoresByStudent(courseID int, studentID int, termID int) ([]*domain.AssessmentScore, error)
+	WithTx(tx *database.Database) IAssessmentRepository
+}
+
+type AssessmentRepository struct {
+	db *database.Database
+}
+
+var (
+	assessmentRepoOnce     sync.Once
+	assessmentRepoInstance *AssessmentRepository
+)
+
+func NewAssessmentRepository(db *database.Database) IAssessmentRepository {
+	assessmentRepoOnce.Do(func() {
+		assessmentRepoInstance = &AssessmentRepository{}
+		assessmentRepoInstance.db = db
+	})
+	return assessmentRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *AssessmentRepository) WithTx(tx *database.Database) IAssessmentRepository {
+	return &AssessmentRepository{db: tx}
+}
+
+const assessmentComponentColumns = "ID, CourseID, Name, Category, Weight, MaxScore"
+
+func scanAssessmentComponent(row rowScanner) (*domain.AssessmentComponent, error) {
+	var c domain.AssessmentComponent
+	err := row.Scan(&c.ID, &c.CourseID, &c.Name, &c.Category, &c.Weight, &c.MaxScore)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *AssessmentRepository) GetComponentsByCourseID(courseID int) ([]*domain.AssessmentComponent, error) {
+	rows, err := r.db.Query("SELECT "+assessmentComponentColumns+" FROM AssessmentComponents WHERE CourseID = ? ORDER BY ID", courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := make([]*domain.AssessmentComponent, 0)
+	for rows.Next() {
+		component, err := scanAssessmentComponent(rows)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func (r *AssessmentRepository) GetComponentByID(id int) (*domain.AssessmentComponent, error) {
+	return scanAssessmentComponent(r.db.QueryRow("SELECT "+assessmentComponentColumns+" FROM AssessmentComponents WHERE ID = ?", id))
+}
+
+func (r *AssessmentRepository) CreateComponent(component *domain.AssessmentComponent) error {
+	result, err := r.db.Exec("INSERT INTO AssessmentComponents (CourseID, Name, Category, Weight, MaxScore) VALUES (?, ?, ?, ?, ?)",
+		component.CourseID, component.Name, component.Category, component.Weight, component.MaxScore)
+	if err != nil {
+		return err
+	}
+
+	componentID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	component.ID = int(componentID)
+
+	return nil
+}
+
+func (r *AssessmentRepository) UpdateComponent(component *domain.AssessmentComponent) error {
+	_, err := r.db.Exec("UPDATE AssessmentComponents SET Name = ?, Category = ?, Weight = ?, MaxScore = ? WHERE ID = ?",
+		component.Name, component.Category, component.Weight, component.MaxScore, component.ID)
+	return err
+}
+
+// DeleteComponent removes a component together with its scores.
+func (r *AssessmentRepository) DeleteComponent(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM AssessmentScores WHERE ComponentID = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM AssessmentComponents WHERE ID = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *AssessmentRepository) GetDropLowest(courseID int) (map[string]int, error) {
+	rows, err := r.db.Query("SELECT Category, DropLowest FROM AssessmentCategories WHERE CourseID = ?", courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dropLowest := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, err
+		}
+		dropLowest[category] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return dropLowest, nil
+}
+
+// SetDropLowest sets how many of the lowest scores of a category are left
+// out. A count of 0 removes the rule.
+func (r *AssessmentRepository) SetDropLowest(courseID int, category string, count int) error {
+	if count == 0 {
+		_, err := r.db.Exec("DELETE FROM AssessmentCategories WHERE CourseID = ? AND Category = ?", courseID, category)
+		return err
+	}
+	_, err := r.db.Exec("REPLACE INTO AssessmentCategories (CourseID, Category, DropLowest) VALUES (?, ?, ?)", courseID, category, count)
+	return err
+}
+
+// SaveScores stores the scores, replacing earlier scores of the same
+// student in the same component and term.
+func (r *AssessmentRepository) SaveScores(scores []domain.AssessmentScore) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, s := range scores {
+		_, err := tx.Exec("REPLACE INTO AssessmentScores (ComponentID, StudentID, TermID, Score) VALUES (?, ?, ?, ?)", s.ComponentID, s.StudentID, s.TermID, s.Score)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AssessmentRepository) GetScoresByCourseID(courseID int) ([]*domain.AssessmentScore, error) {
+	return r.findScores("SELECT s.ComponentID, s.StudentID, s.TermID, s.Score FROM AssessmentScores s INNER JOIN AssessmentComponents c ON c.ID = s.ComponentID WHERE c.CourseID = ?", courseID)
+}
+
+func (r *AssessmentRepository) GetScoresByStudent(courseID int, studentID int, termID int) ([]*domain.AssessmentScore, error) {
+	return r.findScores("SELECT s.ComponentID, s.StudentID, s.TermID, s.Score FROM AssessmentScores s INNER JOIN AssessmentComponents c ON c.ID = s.ComponentID WHERE c.CourseID = ? AND s.StudentID = ? AND s.TermID = ?", courseID, studentID, termID)
+}
+
+func (r *AssessmentRepository) findScores(query string, args ...interface{}) ([]*domain.AssessmentScore, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*domain.AssessmentScore
+	for rows.Next() {
+		var s domain.AssessmentScore
+		if err := rows.Scan(&s.ComponentID, &s.StudentID, &s.TermID, &s.Score); err != nil {
+			return nil, err
+		}
+		scores = append(scores, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
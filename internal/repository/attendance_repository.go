@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IAttendanceRepository interface {
+	// GetSessionsByCourseID lists the sessions of a course; a termID of 0
+	// means every term.
+	GetSessionsByCourseID(courseID int, termID int) ([]*domain.ClassSession, error)
+	GetSessionByID(id int) (*domain.ClassSession, error)
+	CreateSession(session *domain.ClassSession) error
+	UpdateSession(session *domain.ClassSession) error
+	DeleteSession(id int) error
+	GetRecordsBySessionID(sessionID int) ([]*domain.AttendanceRecord, error)
+	SaveRecords(records []domain.AttendanceRecord) error
+	// GetRatesByCourseID and GetRatesByStudentID count the attendance
+	// statuses per student, course and term. Rate and AtRisk are left empty.
+	GetRatesByCourseID(courseID int, termID int) ([]*domain.AttendanceRate, error)
+	GetRatesByStudentID(studentID int, termID int) ([]*domain.AttendanceRate, error)
+	WithTx(tx *database.Database) IAttendanceRepository
+}
+
+type AttendanceRepository struct {
+	db *database.Database
+}
+
+var (
+	attendanceRepoOnce     sync.Once
+	attendanceRepoInstance *AttendanceRepository
+)
+
+func NewAttendanceRepository(db *database.Database) IAttendanceRepository {
+	attendanceRepoOnce.Do(func() {
+		attendanceRepoInstance = &AttendanceRepository{}
+		attendanceRepoInstance.db = db
+	})
+	return attendanceRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *AttendanceRepository) WithTx(tx *database.Database) IAttendanceRepository {
+	return &AttendanceRepository{db: tx}
+}
+
+const classSessionColumns = "ID, CourseID, TermID, SectionID, Date, Topic"
+
+func scanClassSession(row rowScanner) (*domain.ClassSession, error) {
+	var s domain.ClassSession
+	var sectionID sql.NullInt64
+	err := row.Scan(&s.ID, &s.CourseID, &s.TermID, &sectionID, &s.Date, &s.Topic)
+	if err != nil {
+		return nil, err
+	}
+	s.SectionID = nullIntPtr(sectionID)
+	return &s, nil
+}
+
+func (r *AttendanceRepository) GetSessionsByCourseID(courseID int, termID int) ([]*domain.ClassSession, error) {
+	query, args := whereTerm("SELECT "+classSessionColumns+" FROM ClassSessions", "CourseID = ?", courseID, termID)
+	rows, err := r.db.Query(query+" ORDER BY Date, ID", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.ClassSession
+	for rows.Next() {
+		session, err := scanClassSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *AttendanceRepository) GetSessionByID(id int) (*domain.ClassSession, error) {
+	return scanClassSession(r.db.QueryRow("SELECT "+classSessionColumns+" FROM ClassSessions WHERE ID = ?", id))
+}
+
+func (r *AttendanceRepository) CreateSession(session *domain.ClassSession) error {
+	result, err := r.db.Exec("INSERT INTO ClassSessions (CourseID, TermID, SectionID, Date, Topic) VALUES (?, ?, ?, ?, ?)",
+		session.CourseID, session.TermID, session.SectionID, session.Date, session.Topic)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	session.ID = int(sessionID)
+
+	return nil
+}
+
+func (r *AttendanceRepository) UpdateSession(session *domain.ClassSession) error {
+	_, err := r.db.Exec("UPDATE ClassSessions SET Date = ?, Topic = ? WHERE ID = ?", session.Date, session.Topic, session.ID)
+	return err
+}
+
+// DeleteSession removes a session together with its attendance records.
+func (r *AttendanceRepository) DeleteSession(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM AttendanceRecords WHERE SessionID = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM ClassSessions WHERE ID = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *AttendanceRepository) GetRecordsBySessionID(sessionID int) ([]*domain.AttendanceRecord, error) {
+	rows, err := r.db.Query("SELECT SessionID, StudentID, Status FROM AttendanceRecords WHERE SessionID = ? ORDER BY StudentID", sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*domain.AttendanceRecord
+	for rows.Next() {
+		var record domain.AttendanceRecord
+		if err := rows.Scan(&record.SessionID, &record.StudentID, &record.Status); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// SaveRecords stores the records, replacing the earlier record of the same
+// student in the same session.
+func (r *AttendanceRepository) SaveRecords(records []domain.AttendanceRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		_, err := tx.Exec("REPLACE INTO AttendanceRecords (SessionID, StudentID, Status) VALUES (?, ?, ?)", record.SessionID, record.StudentID, record.Status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// attendanceRateQuery counts, for every enrolled student, the sessions of
+// the course held so far: those of the whole course and those of the
+// student's section. A session the student was not marked in counts as an
+// absence and is also reported as unmarked.
+const attendanceRateQuery = `SELECT e.StudentID, s.CourseID, s.TermID, COUNT(*),
+	SUM(r.Status <=> 'present'), SUM(r.Status <=> 'absent' OR r.Status IS NULL), SUM(r.Status <=> 'late'), SUM(r.Status <=> 'excused'), SUM(r.Status IS NULL)
+	FROM ClassSessions s
+	INNER JOIN Enrollment e ON e.CourseID = s.CourseID AND e.TermID = s.TermID AND e.Status <> ?
+		AND (s.SectionID IS NULL OR s.SectionID = e.SectionID)
+	LEFT JOIN AttendanceRecords r ON r.SessionID = s.ID AND r.StudentID = e.StudentID
+	WHERE s.Date <= CURRENT_DATE`
+
+func (r *AttendanceRepository) GetRatesByCourseID(courseID int, termID int) ([]*domain.AttendanceRate, error) {
+	query, args := rateFilter("s.CourseID = ?", courseID, termID)
+	return r.findRates(query+" GROUP BY e.StudentID, s.CourseID, s.TermID ORDER BY s.TermID, e.StudentID", args...)
+}
+
+func (r *AttendanceRepository) GetRatesByStudentID(studentID int, termID int) ([]*domain.AttendanceRate, error) {
+	query, args := rateFilter("e.StudentID = ?", studentID, termID)
+	return r.findRates(query+" GROUP BY e.StudentID, s.CourseID, s.TermID ORDER BY s.TermID, s.CourseID", args...)
+}
+
+// rateFilter narrows attendanceRateQuery down to condition and, unless it
+// is 0, the term. whereTerm can't be used: both tables have a TermID.
+func rateFilter(condition string, arg interface{}, termID int) (string, []interface{}) {
+	query := attendanceRateQuery + " AND " + condition
+	args := []interface{}{domain.EnrollmentStatusWaitlisted, arg}
+	if termID != 0 {
+		query += " AND s.TermID = ?"
+		args = append(args, termID)
+	}
+	return query, args
+}
+
+func (r *AttendanceRepository) findRates(query string, args ...interface{}) ([]*domain.AttendanceRate, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []*domain.AttendanceRate
+	for rows.Next() {
+		var rate domain.AttendanceRate
+		err := rows.Scan(&rate.StudentID, &rate.CourseID, &rate.TermID, &rate.Sessions, &rate.Present, &rate.Absent, &rate.Late, &rate.Excused, &rate.Unmarked)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, &rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
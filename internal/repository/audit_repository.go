@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"strings"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// IAuditRepository is append-only: entries can be added and read but never
+// changed or removed.
+type IAuditRepository interface {
+	Create(entry *domain.AuditEntry) error
+	Find(filter *domain.AuditFilter) ([]*domain.AuditEntry, error)
+	WithTx(tx *database.Database) IAuditRepository
+}
+
+type AuditRepository struct {
+	db *database.Database
+}
+
+var (
+	auditRepoOnce     sync.Once
+	auditRepoInstance *AuditRepository
+)
+
+func NewAuditRepository(db *database.Database) IAuditRepository {
+	auditRepoOnce.Do(func() {
+		auditRepoInstance = &AuditRepository{}
+		auditRepoInstance.db = db
+	})
+	return auditRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *AuditRepository) WithTx(tx *database.Database) IAuditRepository {
+	return &AuditRepository{db: tx}
+}
+
+func (r *AuditRepository) Create(entry *domain.AuditEntry) error {
+	result, err := r.db.Exec("INSERT INTO AuditLog (ActorUserID, ActorUsername, ActorRole, Action, Entity, EntityID, BeforeData, AfterData, CreatedAt) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		entry.ActorUserID, entry.ActorUsername, entry.ActorRole, entry.Action, entry.Entity, entry.EntityID, nullJSON(entry.Before), nullJSON(entry.After), entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	entryID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = int(entryID)
+
+	return nil
+}
+
+// Find returns the matching entries, newest first.
+func (r *AuditRepository) Find(filter *domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Entity != "" {
+		conditions = append(conditions, "Entity = ?")
+		args = append(args, filter.Entity)
+	}
+	if filter.EntityID != 0 {
+		conditions = append(conditions, "EntityID = ?")
+		args = append(args, filter.EntityID)
+	}
+	if filter.ActorUserID != 0 {
+		conditions = append(conditions, "ActorUserID = ?")
+		args = append(args, filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "Action = ?")
+		args = append(args, filter.Action)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "CreatedAt >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "CreatedAt < ?")
+		args = append(args, filter.To)
+	}
+
+	query := "SELECT ID, ActorUserID, ActorUsername, ActorRole, Action, Entity, EntityID, BeforeData, AfterData, CreatedAt FROM AuditLog"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY ID DESC LIMIT ? OFFSET ?"
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		var actorUserID sql.NullInt64
+		var before, after sql.NullString
+		var createdAt mysql.NullTime
+		err = rows.Scan(&e.ID, &actorUserID, &e.ActorUsername, &e.ActorRole, &e.Action, &e.Entity, &e.EntityID, &before, &after, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		e.ActorUserID = nullIntPtr(actorUserID)
+		if before.Valid {
+			e.Before = []byte(before.String)
+		}
+		if after.Valid {
+			e.After = []byte(after.String)
+		}
+		e.CreatedAt = createdAt.Time
+		entries = append(entries, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
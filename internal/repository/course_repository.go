@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"sync"
@@ -12,6 +13,7 @@ type ICourseRepository interface {
 	Create(course *domain.Course) error
 	Update(course *domain.Course) error
 	Delete(id int) error
+	WithTx(tx *database.Database) ICourseRepository
 }
 
 type CourseRepository struct {
@@ -31,8 +33,26 @@ func NewCourseRepository(db *database.Database) ICourseRepository {
 	return courseRepoInstance
 }
 
+// WithTx returns the repository running its statements in tx.
+func (r *CourseRepository) WithTx(tx *database.Database) ICourseRepository {
+	return &CourseRepository{db: tx}
+}
+
+const courseColumns = "ID, Name, Description, Credits, GradingScaleID"
+
+func scanCourse(row rowScanner) (*domain.Course, error) {
+	course := new(domain.Course)
+	var gradingScaleID sql.NullInt64
+	err := row.Scan(&course.ID, &course.Name, &course.Description, &course.Credits, &gradingScaleID)
+	if err != nil {
+		return nil, err
+	}
+	course.GradingScaleID = nullIntPtr(gradingScaleID)
+	return course, nil
+}
+
 func (r *CourseRepository) GetAll() ([]*domain.Course, error) {
-	rows, err := r.db.Query("SELECT ID, Name, Description FROM Courses")
+	rows, err := r.db.Query("SELECT " + courseColumns + " FROM Courses")
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +60,7 @@ func (r *CourseRepository) GetAll() ([]*domain.Course, error) {
 
 	courses := make([]*domain.Course, 0)
 	for rows.Next() {
-		course := new(domain.Course)
-		err := rows.Scan(&course.ID, &course.Name, &course.Description)
+		course, err := scanCourse(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -52,8 +71,7 @@ func (r *CourseRepository) GetAll() ([]*domain.Course, error) {
 }
 
 func (r *CourseRepository) GetByID(id int) (*domain.Course, error) {
-	course := new(domain.Course)
-	err := r.db.QueryRow("SELECT ID, Name, Description FROM Courses WHERE ID = ?", id).Scan(&course.ID, &course.Name, &course.Description)
+	course, err := scanCourse(r.db.QueryRow("SELECT "+courseColumns+" FROM Courses WHERE ID = ?", id))
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +80,7 @@ func (r *CourseRepository) GetByID(id int) (*domain.Course, error) {
 }
 
 func (r *CourseRepository) Create(course *domain.Course) error {
-	result, err := r.db.Exec("INSERT INTO Courses (Name, Description) VALUES (?, ?)", course.Name, course.Description)
+	result, err := r.db.Exec("INSERT INTO Courses (Name, Description, Credits, GradingScaleID) VALUES (?, ?, ?, ?)", course.Name, course.Description, course.Credits, course.GradingScaleID)
 	if err != nil {
 		return err
 	}
@@ -76,7 +94,7 @@ func (r *CourseRepository) Create(course *domain.Course) error {
 }
 
 func (r *CourseRepository) Update(course *domain.Course) error {
-	_, err := r.db.Exec("UPDATE Courses SET Name = ?, Description = ? WHERE ID = ?", course.Name, course.Description, course.ID)
+	_, err := r.db.Exec("UPDATE Courses SET Name = ?, Description = ?, Credits = ?, GradingScaleID = ? WHERE ID = ?", course.Name, course.Description, course.Credits, course.GradingScaleID, course.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IDepartmentRepository interface {
+	GetAll() ([]*domain.Department, error)
+	GetByID(id int) (*domain.Department, error)
+	Create(department *domain.Department) error
+	Update(department *domain.Department) error
+	Delete(id int) error
+	IsUsed(id int) (bool, error)
+	WithTx(tx *database.Database) IDepartmentRepository
+}
+
+type DepartmentRepository struct {
+	db *database.Database
+}
+
+var (
+	departmentRepoOnce     sync.Once
+	departmentRepoInstance *DepartmentRepository
+)
+
+func NewDepartmentRepository(db *database.Database) IDepartmentRepository {
+	departmentRepoOnce.Do(func() {
+		departmentRepoInstance = &DepartmentRepository{}
+		departmentRepoInstance.db = db
+	})
+	return departmentRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *DepartmentRepository) WithTx(tx *database.Database) IDepartmentRepository {
+	return &DepartmentRepository{db: tx}
+}
+
+func (r *DepartmentRepository) GetAll() ([]*domain.Department, error) {
+	rows, err := r.db.Query("SELECT ID, Name, Code FROM Departments ORDER BY Name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var departments []*domain.Department
+	for rows.Next() {
+		var d domain.Department
+		if err := rows.Scan(&d.ID, &d.Name, &d.Code); err != nil {
+			return nil, err
+		}
+		departments = append(departments, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return departments, nil
+}
+
+func (r *DepartmentRepository) GetByID(id int) (*domain.Department, error) {
+	var d domain.Department
+	err := r.db.QueryRow("SELECT ID, Name, Code FROM Departments WHERE ID = ?", id).Scan(&d.ID, &d.Name, &d.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *DepartmentRepository) Create(department *domain.Department) error {
+	result, err := r.db.Exec("INSERT INTO Departments (Name, Code) VALUES (?, ?)", department.Name, department.Code)
+	if err != nil {
+		return err
+	}
+
+	departmentID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	department.ID = int(departmentID)
+
+	return nil
+}
+
+func (r *DepartmentRepository) Update(department *domain.Department) error {
+	_, err := r.db.Exec("UPDATE Departments SET Name = ?, Code = ? WHERE ID = ?", department.Name, department.Code, department.ID)
+	return err
+}
+
+func (r *DepartmentRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM Departments WHERE ID = ?", id)
+	return err
+}
+
+// IsUsed reports whether a professor or a program belongs to the
+// department.
+func (r *DepartmentRepository) IsUsed(id int) (bool, error) {
+	var used bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM Professors WHERE DepartmentID = ?) OR EXISTS(SELECT 1 FROM Programs WHERE DepartmentID = ?)", id, id).Scan(&used)
+	return used, err
+}
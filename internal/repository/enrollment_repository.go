@@ -1,21 +1,34 @@
 package repository
 
 import (
+	"database/sql"
 	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"sync"
 )
 
+// IEnrollmentRepository lists enrollments filtered by term; a termID of 0
+// means every term.
 type IEnrollmentRepository interface {
-	GetAll() ([]*domain.Enrollment, error)
+	GetAll(termID int) ([]*domain.Enrollment, error)
 	GetByID(id int) (*domain.Enrollment, error)
 	Create(enrollment *domain.Enrollment) error
 	Update(enrollment *domain.Enrollment) error
 	Delete(id int) error
-	GetByStudentID(studentID int) ([]*domain.Enrollment, error)
-	GetByCourseID(courseID int) ([]*domain.Enrollment, error)
+	GetByStudentID(studentID int, termID int) ([]*domain.Enrollment, error)
+	GetByCourseID(courseID int, termID int) ([]*domain.Enrollment, error)
+	GetBySectionID(sectionID int) ([]*domain.Enrollment, error)
+	Enroll(enrollment *domain.Enrollment) error
+	Drop(id int, check PromotionCheck) ([]*domain.Enrollment, error)
+	FillSection(sectionID int, check PromotionCheck) ([]*domain.Enrollment, error)
+	WithTx(tx *database.Database) IEnrollmentRepository
 }
 
+// PromotionCheck reports whether a waitlisted enrollment may take a free
+// seat. It runs while the section is locked, so its reads should go through
+// the same transaction.
+type PromotionCheck func(enrollment *domain.Enrollment) (bool, error)
+
 type EnrollmentRepository struct {
 	db *database.Database
 }
@@ -33,8 +46,30 @@ func NewEnrollmentRepository(db *database.Database) IEnrollmentRepository {
 	return enrollmentRepoInstance
 }
 
-func (r *EnrollmentRepository) GetAll() ([]*domain.Enrollment, error) {
-	rows, err := r.db.Query("SELECT * FROM Enrollment")
+// WithTx returns the repository running its statements in tx.
+func (r *EnrollmentRepository) WithTx(tx *database.Database) IEnrollmentRepository {
+	return &EnrollmentRepository{db: tx}
+}
+
+const enrollmentColumns = "ID, StudentID, CourseID, TermID, SectionID, Status"
+
+func scanEnrollment(row rowScanner) (*domain.Enrollment, error) {
+	enrollment := &domain.Enrollment{}
+	var sectionID sql.NullInt64
+	err := row.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.CourseID, &enrollment.TermID, &sectionID, &enrollment.Status)
+	if err != nil {
+		return nil, err
+	}
+	enrollment.SectionID = nullIntPtr(sectionID)
+	return enrollment, nil
+}
+
+// find returns the enrollments matching condition, a "Column = ?"
+// comparison with one argument or "" for none, restricted to termID unless
+// it is 0.
+func (r *EnrollmentRepository) find(condition string, arg interface{}, termID int) ([]*domain.Enrollment, error) {
+	query, args := whereTerm("SELECT "+enrollmentColumns+" FROM Enrollment", condition, arg, termID)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,37 +77,36 @@ func (r *EnrollmentRepository) GetAll() ([]*domain.Enrollment, error) {
 
 	var enrollments []*domain.Enrollment
 	for rows.Next() {
-		enrollment := &domain.Enrollment{}
-		err := rows.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.CourseID)
+		enrollment, err := scanEnrollment(rows)
 		if err != nil {
 			return nil, err
 		}
 		enrollments = append(enrollments, enrollment)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return enrollments, nil
 }
 
-func (r *EnrollmentRepository) GetByID(id int) (*domain.Enrollment, error) {
-	row := r.db.QueryRow("SELECT * FROM Enrollment WHERE ID = ?", id)
-
-	enrollment := &domain.Enrollment{}
-	err := row.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.CourseID)
-	if err != nil {
-		return nil, err
-	}
+func (r *EnrollmentRepository) GetAll(termID int) ([]*domain.Enrollment, error) {
+	return r.find("", nil, termID)
+}
 
-	return enrollment, nil
+func (r *EnrollmentRepository) GetByID(id int) (*domain.Enrollment, error) {
+	return scanEnrollment(r.db.QueryRow("SELECT "+enrollmentColumns+" FROM Enrollment WHERE ID = ?", id))
 }
 
 func (r *EnrollmentRepository) Create(enrollment *domain.Enrollment) error {
-	stmt, err := r.db.Prepare("INSERT INTO Enrollment (StudentID, CourseID) VALUES (?, ?)")
+	stmt, err := r.db.Prepare("INSERT INTO Enrollment (StudentID, CourseID, TermID, SectionID, Status) VALUES (?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(enrollment.StudentID, enrollment.CourseID)
+	result, err := stmt.Exec(enrollment.StudentID, enrollment.CourseID, enrollment.TermID, enrollment.SectionID, enrollment.Status)
 	if err != nil {
 		return err
 	}
@@ -87,13 +121,13 @@ func (r *EnrollmentRepository) Create(enrollment *domain.Enrollment) error {
 }
 
 func (r *EnrollmentRepository) Update(enrollment *domain.Enrollment) error {
-	stmt, err := r.db.Prepare("UPDATE Enrollment SET StudentID = ?, CourseID = ? WHERE ID = ?")
+	stmt, err := r.db.Prepare("UPDATE Enrollment SET StudentID = ?, CourseID = ?, TermID = ? WHERE ID = ?")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(enrollment.StudentID, enrollment.CourseID, enrollment.ID)
+	_, err = stmt.Exec(enrollment.StudentID, enrollment.CourseID, enrollment.TermID, enrollment.ID)
 	if err != nil {
 		return err
 	}
@@ -115,8 +149,16 @@ func (r *EnrollmentRepository) Delete(id int) error {
 	return nil
 }
 
-func (r *EnrollmentRepository) GetByStudentID(studentID int) ([]*domain.Enrollment, error) {
-	rows, err := r.db.Query("SELECT * FROM Enrollment WHERE StudentID = ?", studentID)
+func (r *EnrollmentRepository) GetByStudentID(studentID int, termID int) ([]*domain.Enrollment, error) {
+	return r.find("StudentID = ?", studentID, termID)
+}
+
+func (r *EnrollmentRepository) GetByCourseID(courseID int, termID int) ([]*domain.Enrollment, error) {
+	return r.find("CourseID = ?", courseID, termID)
+}
+
+func (r *EnrollmentRepository) GetBySectionID(sectionID int) ([]*domain.Enrollment, error) {
+	rows, err := r.db.Query("SELECT "+enrollmentColumns+" FROM Enrollment WHERE SectionID = ? ORDER BY ID", sectionID)
 	if err != nil {
 		return nil, err
 	}
@@ -124,33 +166,191 @@ func (r *EnrollmentRepository) GetByStudentID(studentID int) ([]*domain.Enrollme
 
 	var enrollments []*domain.Enrollment
 	for rows.Next() {
-		enrollment := &domain.Enrollment{}
-		err := rows.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.CourseID)
+		enrollment, err := scanEnrollment(rows)
 		if err != nil {
 			return nil, err
 		}
 		enrollments = append(enrollments, enrollment)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return enrollments, nil
 }
 
-func (r *EnrollmentRepository) GetByCourseID(courseID int) ([]*domain.Enrollment, error) {
-	rows, err := r.db.Query("SELECT * FROM Enrollment WHERE CourseID = ?", courseID)
+// Enroll stores an enrollment in its section and sets its status. The
+// section row stays locked while the seats are counted, so two requests
+// can't both take the last seat. A full section waitlists the enrollment
+// when its waitlist is enabled and refuses it with domain.ErrSectionFull
+// otherwise.
+func (r *EnrollmentRepository) Enroll(enrollment *domain.Enrollment) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	free, waitlist, err := lockSection(tx, *enrollment.SectionID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case free > 0:
+		enrollment.Status = domain.EnrollmentStatusEnrolled
+	case waitlist:
+		enrollment.Status = domain.EnrollmentStatusWaitlisted
+	default:
+		return domain.ErrSectionFull
+	}
+
+	result, err := tx.Exec("INSERT INTO Enrollment (StudentID, CourseID, TermID, SectionID, Status) VALUES (?, ?, ?, ?, ?)",
+		enrollment.StudentID, enrollment.CourseID, enrollment.TermID, enrollment.SectionID, enrollment.Status)
+	if err != nil {
+		return err
+	}
+
+	enrollmentID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	enrollment.ID = int(enrollmentID)
+
+	return tx.Commit()
+}
+
+// Drop deletes an enrollment and, when it held a seat in a section, gives
+// the seat to the first waitlisted enrollment that passes check, in the
+// same transaction. It returns the promoted enrollments.
+func (r *EnrollmentRepository) Drop(id int, check PromotionCheck) ([]*domain.Enrollment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enrollment, err := scanEnrollment(tx.QueryRow("SELECT "+enrollmentColumns+" FROM Enrollment WHERE ID = ?", id))
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted []*domain.Enrollment
+	if enrollment.SectionID == nil {
+		if _, err := tx.Exec("DELETE FROM Enrollment WHERE ID = ?", id); err != nil {
+			return nil, err
+		}
+	} else {
+		// Lock the section before deleting so the count in promote is exact.
+		if _, _, err := lockSection(tx, *enrollment.SectionID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec("DELETE FROM Enrollment WHERE ID = ?", id); err != nil {
+			return nil, err
+		}
+		promoted, err = promote(tx, *enrollment.SectionID, check)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
+
+// FillSection promotes waitlisted enrollments that pass check while the
+// section has free seats, for example after its capacity grew. It returns
+// the promoted enrollments.
+func (r *EnrollmentRepository) FillSection(sectionID int, check PromotionCheck) ([]*domain.Enrollment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	promoted, err := promote(tx, sectionID, check)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
+
+// lockSection locks the section row until the transaction ends and returns
+// its free seats and whether it has a waitlist.
+func lockSection(tx *database.Tx, sectionID int) (int, bool, error) {
+	var capacity int
+	var waitlist bool
+	err := tx.QueryRow("SELECT Capacity, Waitlist FROM Sections WHERE ID = ? FOR UPDATE", sectionID).Scan(&capacity, &waitlist)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var enrolled int
+	err = tx.QueryRow("SELECT COUNT(*) FROM Enrollment WHERE SectionID = ? AND Status = ?", sectionID, domain.EnrollmentStatusEnrolled).Scan(&enrolled)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return capacity - enrolled, waitlist, nil
+}
+
+// promote moves the oldest waitlisted enrollments of the section that pass
+// check into its free seats. The ones check rejects stay waitlisted.
+func promote(tx *database.Tx, sectionID int, check PromotionCheck) ([]*domain.Enrollment, error) {
+	free, _, err := lockSection(tx, sectionID)
+	if err != nil || free <= 0 {
+		return nil, err
+	}
+
+	rows, err := tx.Query("SELECT "+enrollmentColumns+" FROM Enrollment WHERE SectionID = ? AND Status = ? ORDER BY ID FOR UPDATE",
+		sectionID, domain.EnrollmentStatusWaitlisted)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var enrollments []*domain.Enrollment
+	var waitlisted []*domain.Enrollment
 	for rows.Next() {
-		enrollment := &domain.Enrollment{}
-		err := rows.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.CourseID)
+		enrollment, err := scanEnrollment(rows)
 		if err != nil {
 			return nil, err
 		}
-		enrollments = append(enrollments, enrollment)
+		waitlisted = append(waitlisted, enrollment)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
 
-	return enrollments, nil
+	var promoted []*domain.Enrollment
+	for _, enrollment := range waitlisted {
+		if len(promoted) == free {
+			break
+		}
+		ok, err := check(enrollment)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE Enrollment SET Status = ? WHERE ID = ?", domain.EnrollmentStatusEnrolled, enrollment.ID)
+		if err != nil {
+			return nil, err
+		}
+		enrollment.Status = domain.EnrollmentStatusEnrolled
+		promoted = append(promoted, enrollment)
+	}
+
+	return promoted, nil
 }
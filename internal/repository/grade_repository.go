@@ -6,15 +6,18 @@ import (
 	"sync"
 )
 
+// IGradeRepository lists grades filtered by term; a termID of 0 means every
+// term.
 type IGradeRepository interface {
-	GetAll() ([]*domain.Grade, error)
+	GetAll(termID int) ([]*domain.Grade, error)
 	GetByID(id int) (*domain.Grade, error)
 	Create(grade *domain.Grade) error
 	Update(grade *domain.Grade) error
 	Delete(id int) error
-	GetByStudentID(studentID int) ([]*domain.Grade, error)
-	GetByCourseID(courseID int) ([]*domain.Grade, error)
-	GetByProfessorID(professorID int) ([]*domain.Grade, error)
+	GetByStudentID(studentID int, termID int) ([]*domain.Grade, error)
+	GetByCourseID(courseID int, termID int) ([]*domain.Grade, error)
+	GetByProfessorID(professorID int, termID int) ([]*domain.Grade, error)
+	WithTx(tx *database.Database) IGradeRepository
 }
 
 type GradeRepository struct {
@@ -34,8 +37,27 @@ func NewGradeRepository(db *database.Database) IGradeRepository {
 	return gradeRepoInstance
 }
 
-func (r *GradeRepository) GetAll() ([]*domain.Grade, error) {
-	rows, err := r.db.Query("SELECT * FROM Grades")
+// WithTx returns the repository running its statements in tx.
+func (r *GradeRepository) WithTx(tx *database.Database) IGradeRepository {
+	return &GradeRepository{db: tx}
+}
+
+const gradeColumns = "ID, StudentID, CourseID, ProfessorID, TermID, Grade"
+
+func scanGrade(row rowScanner) (*domain.Grade, error) {
+	grade := &domain.Grade{}
+	err := row.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.TermID, &grade.Grade)
+	if err != nil {
+		return nil, err
+	}
+	return grade, nil
+}
+
+// find returns the grades matching condition, a "Column = ?" comparison with
+// one argument or "" for none, restricted to termID unless it is 0.
+func (r *GradeRepository) find(condition string, arg interface{}, termID int) ([]*domain.Grade, error) {
+	query, args := whereTerm("SELECT "+gradeColumns+" FROM Grades", condition, arg, termID)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,31 +65,30 @@ func (r *GradeRepository) GetAll() ([]*domain.Grade, error) {
 
 	var grades []*domain.Grade
 	for rows.Next() {
-		grade := &domain.Grade{}
-		err := rows.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.Grade)
+		grade, err := scanGrade(rows)
 		if err != nil {
 			return nil, err
 		}
 		grades = append(grades, grade)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return grades, nil
 }
 
-func (r *GradeRepository) GetByID(id int) (*domain.Grade, error) {
-	row := r.db.QueryRow("SELECT * FROM Grades WHERE ID = ?", id)
-
-	grade := &domain.Grade{}
-	err := row.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.Grade)
-	if err != nil {
-		return nil, err
-	}
+func (r *GradeRepository) GetAll(termID int) ([]*domain.Grade, error) {
+	return r.find("", nil, termID)
+}
 
-	return grade, nil
+func (r *GradeRepository) GetByID(id int) (*domain.Grade, error) {
+	return scanGrade(r.db.QueryRow("SELECT "+gradeColumns+" FROM Grades WHERE ID = ?", id))
 }
 
 func (r *GradeRepository) Create(grade *domain.Grade) error {
-	result, err := r.db.Exec("INSERT INTO Grades (StudentID, CourseID, ProfessorID, Grade) VALUES (?, ?, ?, ?)", grade.StudentID, grade.CourseID, grade.ProfessorID, grade.Grade)
+	result, err := r.db.Exec("INSERT INTO Grades (StudentID, CourseID, ProfessorID, TermID, Grade) VALUES (?, ?, ?, ?, ?)", grade.StudentID, grade.CourseID, grade.ProfessorID, grade.TermID, grade.Grade)
 	if err != nil {
 		return err
 	}
@@ -82,7 +103,7 @@ func (r *GradeRepository) Create(grade *domain.Grade) error {
 }
 
 func (r *GradeRepository) Update(grade *domain.Grade) error {
-	_, err := r.db.Exec("UPDATE Grades SET StudentID = ?, CourseID = ?, ProfessorID = ?, Grade = ? WHERE ID = ?", grade.StudentID, grade.CourseID, grade.ProfessorID, grade.Grade, grade.ID)
+	_, err := r.db.Exec("UPDATE Grades SET StudentID = ?, CourseID = ?, ProfessorID = ?, TermID = ?, Grade = ? WHERE ID = ?", grade.StudentID, grade.CourseID, grade.ProfessorID, grade.TermID, grade.Grade, grade.ID)
 	if err != nil {
 		return err
 	}
@@ -99,62 +120,14 @@ func (r *GradeRepository) Delete(id int) error {
 	return nil
 }
 
-func (r *GradeRepository) GetByStudentID(studentID int) ([]*domain.Grade, error) {
-	rows, err := r.db.Query("SELECT * FROM Grades WHERE StudentID = ?", studentID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var grades []*domain.Grade
-	for rows.Next() {
-		grade := &domain.Grade{}
-		err := rows.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.Grade)
-		if err != nil {
-			return nil, err
-		}
-		grades = append(grades, grade)
-	}
-
-	return grades, nil
+func (r *GradeRepository) GetByStudentID(studentID int, termID int) ([]*domain.Grade, error) {
+	return r.find("StudentID = ?", studentID, termID)
 }
 
-func (r *GradeRepository) GetByCourseID(courseID int) ([]*domain.Grade, error) {
-	rows, err := r.db.Query("SELECT * FROM Grades WHERE CourseID = ?", courseID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var grades []*domain.Grade
-	for rows.Next() {
-		grade := &domain.Grade{}
-		err := rows.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.Grade)
-		if err != nil {
-			return nil, err
-		}
-		grades = append(grades, grade)
-	}
-
-	return grades, nil
+func (r *GradeRepository) GetByCourseID(courseID int, termID int) ([]*domain.Grade, error) {
+	return r.find("CourseID = ?", courseID, termID)
 }
 
-func (r *GradeRepository) GetByProfessorID(professorID int) ([]*domain.Grade, error) {
-	rows, err := r.db.Query("SELECT * FROM Grades WHERE ProfessorID = ?", professorID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var grades []*domain.Grade
-	for rows.Next() {
-		grade := &domain.Grade{}
-		err := rows.Scan(&grade.ID, &grade.StudentID, &grade.CourseID, &grade.ProfessorID, &grade.Grade)
-		if err != nil {
-			return nil, err
-		}
-		grades = append(grades, grade)
-	}
-
-	return grades, nil
+func (r *GradeRepository) GetByProfessorID(professorID int, termID int) ([]*domain.Grade, error) {
+	return r.find("ProfessorID = ?", professorID, termID)
 }
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IGradingScaleRepository interface {
+	GetAll() ([]*domain.GradingScale, error)
+	GetByID(id int) (*domain.GradingScale, error)
+	// GetByCourseID returns the scale of a course, or nil when the course
+	// has none.
+	GetByCourseID(courseID int) (*domain.GradingScale, error)
+	Create(scale *domain.GradingScale) error
+	Update(scale *domain.GradingScale) error
+	Delete(id int) error
+	IsUsed(id int) (bool, error)
+	WithTx(tx *database.Database) IGradingScaleRepository
+}
+
+type GradingScaleRepository struct {
+	db *database.Database
+}
+
+var (
+	gradingScaleRepoOnce     sync.Once
+	gradingScaleRepoInstance *GradingScaleRepository
+)
+
+func NewGradingScaleRepository(db *database.Database) IGradingScaleRepository {
+	gradingScaleRepoOnce.Do(func() {
+		gradingScaleRepoInstance = &GradingScaleRepository{}
+		gradingScaleRepoInstance.db = db
+	})
+	return gradingScaleRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *GradingScaleRepository) WithTx(tx *database.Database) IGradingScaleRepository {
+	return &GradingScaleRepository{db: tx}
+}
+
+func (r *GradingScaleRepository) GetAll() ([]*domain.GradingScale, error) {
+	rows, err := r.db.Query("SELECT ID, Name, MinGrade, MaxGrade FROM GradingScales ORDER BY Name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scales []*domain.GradingScale
+	for rows.Next() {
+		var s domain.GradingScale
+		if err := rows.Scan(&s.ID, &s.Name, &s.MinGrade, &s.MaxGrade); err != nil {
+			return nil, err
+		}
+		scales = append(scales, &s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, scale := range scales {
+		if scale.Ranges, err = r.ranges(scale.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return scales, nil
+}
+
+func (r *GradingScaleRepository) GetByID(id int) (*domain.GradingScale, error) {
+	var s domain.GradingScale
+	err := r.db.QueryRow("SELECT ID, Name, MinGrade, MaxGrade FROM GradingScales WHERE ID = ?", id).Scan(&s.ID, &s.Name, &s.MinGrade, &s.MaxGrade)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Ranges, err = r.ranges(s.ID); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *GradingScaleRepository) GetByCourseID(courseID int) (*domain.GradingScale, error) {
+	var scaleID sql.NullInt64
+	err := r.db.QueryRow("SELECT GradingScaleID FROM Courses WHERE ID = ?", courseID).Scan(&scaleID)
+	if err != nil {
+		return nil, err
+	}
+	if !scaleID.Valid {
+		return nil, nil
+	}
+
+	return r.GetByID(int(scaleID.Int64))
+}
+
+// ranges returns the ranges of a scale sorted by MinGrade.
+func (r *GradingScaleRepository) ranges(scaleID int) ([]domain.GradeRange, error) {
+	rows, err := r.db.Query("SELECT Letter, MinGrade, Passing, Points FROM GradeRanges WHERE ScaleID = ? ORDER BY MinGrade", scaleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranges []domain.GradeRange
+	for rows.Next() {
+		var gr domain.GradeRange
+		var points sql.NullFloat64
+		if err := rows.Scan(&gr.Letter, &gr.MinGrade, &gr.Passing, &points); err != nil {
+			return nil, err
+		}
+		if points.Valid {
+			gr.Points = &points.Float64
+		}
+		ranges = append(ranges, gr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+func (r *GradingScaleRepository) Create(scale *domain.GradingScale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("INSERT INTO GradingScales (Name, MinGrade, MaxGrade) VALUES (?, ?, ?)", scale.Name, scale.MinGrade, scale.MaxGrade)
+	if err != nil {
+		return err
+	}
+
+	scaleID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if err := insertRanges(tx, int(scaleID), scale.Ranges); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	scale.ID = int(scaleID)
+
+	return nil
+}
+
+// Update changes the scale and replaces its ranges.
+func (r *GradingScaleRepository) Update(scale *domain.GradingScale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE GradingScales SET Name = ?, MinGrade = ?, MaxGrade = ? WHERE ID = ?", scale.Name, scale.MinGrade, scale.MaxGrade, scale.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM GradeRanges WHERE ScaleID = ?", scale.ID); err != nil {
+		return err
+	}
+	if err := insertRanges(tx, scale.ID, scale.Ranges); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertRanges(tx *database.Tx, scaleID int, ranges []domain.GradeRange) error {
+	for _, gr := range ranges {
+		_, err := tx.Exec("INSERT INTO GradeRanges (ScaleID, Letter, MinGrade, Passing, Points) VALUES (?, ?, ?, ?, ?)", scaleID, gr.Letter, gr.MinGrade, gr.Passing, gr.Points)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GradingScaleRepository) Delete(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM GradeRanges WHERE ScaleID = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM GradingScales WHERE ID = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// IsUsed reports whether a course uses the scale.
+func (r *GradingScaleRepository) IsUsed(id int) (bool, error) {
+	var used bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM Courses WHERE GradingScaleID = ?)", id).Scan(&used)
+	if err != nil {
+		return false, err
+	}
+
+	return used, nil
+}
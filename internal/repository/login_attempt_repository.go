@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"golang-technical-test/internal/domain"
+	"sync"
+	"time"
+)
+
+// ILoginAttemptRepository stores failed login counters by key (a username
+// or a client IP). Implementations shared between instances let the lockout
+// apply across the whole deployment.
+type ILoginAttemptRepository interface {
+	Get(key string) (*domain.LoginAttempts, error)
+	RecordFailure(key string, at time.Time) (*domain.LoginAttempts, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+}
+
+// InMemoryLoginAttemptRepository keeps the counters in process memory.
+// Counters without a failure for longer than retention, and not locked,
+// are dropped.
+type InMemoryLoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]*domain.LoginAttempts
+	retention time.Duration
+}
+
+var (
+	loginAttemptRepoOnce     sync.Once
+	loginAttemptRepoInstance *InMemoryLoginAttemptRepository
+)
+
+func NewInMemoryLoginAttemptRepository(retention time.Duration) ILoginAttemptRepository {
+	loginAttemptRepoOnce.Do(func() {
+		loginAttemptRepoInstance = &InMemoryLoginAttemptRepository{
+			attempts:  make(map[string]*domain.LoginAttempts),
+			retention: retention,
+		}
+	})
+	return loginAttemptRepoInstance
+}
+
+func (r *InMemoryLoginAttemptRepository) Get(key string) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *attempts
+	return &copied, nil
+}
+
+func (r *InMemoryLoginAttemptRepository) RecordFailure(key string, at time.Time) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Counters of usernames and IPs that stopped failing are dropped here,
+	// so made-up usernames don't make the map grow forever.
+	r.prune(at)
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{}
+		r.attempts[key] = attempts
+	}
+	attempts.Failures++
+	attempts.LastFailure = at
+
+	copied := *attempts
+	return &copied, nil
+}
+
+func (r *InMemoryLoginAttemptRepository) Lock(key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{}
+		r.attempts[key] = attempts
+	}
+	attempts.LockedUntil = until
+
+	return nil
+}
+
+func (r *InMemoryLoginAttemptRepository) Reset(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+
+	return nil
+}
+
+func (r *InMemoryLoginAttemptRepository) prune(now time.Time) {
+	for key, attempts := range r.attempts {
+		if now.Sub(attempts.LastFailure) > r.retention && now.After(attempts.LockedUntil) {
+			delete(r.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"golang-technical-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestInMemoryLoginAttemptRepository(t *testing.T) {
+	repo := &InMemoryLoginAttemptRepository{attempts: make(map[string]*domain.LoginAttempts), retention: time.Hour}
+	now := time.Now()
+
+	for i := 1; i <= 3; i++ {
+		attempts, err := repo.RecordFailure("user:alice", now)
+		if err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+		if attempts.Failures != i {
+			t.Errorf("Failures = %d, want %d", attempts.Failures, i)
+		}
+	}
+
+	// Callers get a copy, not the stored counter.
+	attempts, _ := repo.Get("user:alice")
+	attempts.Failures = 0
+	if attempts, _ := repo.Get("user:alice"); attempts.Failures != 3 {
+		t.Errorf("stored Failures = %d after changing a copy, want 3", attempts.Failures)
+	}
+
+	if err := repo.Reset("user:alice"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if attempts, _ := repo.Get("user:alice"); attempts != nil {
+		t.Errorf("Get() after Reset() = %+v, want nil", attempts)
+	}
+}
+
+func TestInMemoryLoginAttemptRepositoryPrune(t *testing.T) {
+	repo := &InMemoryLoginAttemptRepository{attempts: make(map[string]*domain.LoginAttempts), retention: time.Hour}
+	now := time.Now()
+
+	repo.RecordFailure("user:stale", now.Add(-2*time.Hour))
+	repo.RecordFailure("user:recent", now.Add(-30*time.Minute))
+	repo.RecordFailure("user:locked", now.Add(-2*time.Hour))
+	repo.Lock("user:locked", now.Add(time.Minute))
+
+	if _, err := repo.RecordFailure("user:new", now); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		kept bool
+	}{
+		{"user:stale", false},
+		{"user:recent", true},
+		{"user:locked", true},
+		{"user:new", true},
+	}
+	for _, tt := range tests {
+		if attempts, _ := repo.Get(tt.key); (attempts != nil) != tt.kept {
+			t.Errorf("%s kept = %v, want %v", tt.key, attempts != nil, tt.kept)
+		}
+	}
+}
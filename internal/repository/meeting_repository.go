@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IMeetingRepository interface {
+	// GetByCourseID lists the meetings of a course, of every section; a
+	// termID of 0 means every term.
+	GetByCourseID(courseID int, termID int) ([]*domain.Meeting, error)
+	// GetByRoomID lists the meetings held in a room in a term.
+	GetByRoomID(roomID int, termID int) ([]*domain.Meeting, error)
+	GetByID(id int) (*domain.Meeting, error)
+	Create(meeting *domain.Meeting) error
+	Update(meeting *domain.Meeting) error
+	Delete(id int) error
+	WithTx(tx *database.Database) IMeetingRepository
+}
+
+type MeetingRepository struct {
+	db *database.Database
+}
+
+var (
+	meetingRepoOnce     sync.Once
+	meetingRepoInstance *MeetingRepository
+)
+
+func NewMeetingRepository(db *database.Database) IMeetingRepository {
+	meetingRepoOnce.Do(func() {
+		meetingRepoInstance = &MeetingRepository{}
+		meetingRepoInstance.db = db
+	})
+	return meetingRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *MeetingRepository) WithTx(tx *database.Database) IMeetingRepository {
+	return &MeetingRepository{db: tx}
+}
+
+const meetingColumns = "ID, CourseID, TermID, SectionID, Day, TIME_FORMAT(StartTime, '%H:%i'), TIME_FORMAT(EndTime, '%H:%i'), RoomID"
+
+func scanMeeting(row rowScanner) (*domain.Meeting, error) {
+	var m domain.Meeting
+	var sectionID, roomID sql.NullInt64
+	err := row.Scan(&m.ID, &m.CourseID, &m.TermID, &sectionID, &m.Day, &m.StartTime, &m.EndTime, &roomID)
+	if err != nil {
+		return nil, err
+	}
+	m.SectionID = nullIntPtr(sectionID)
+	m.RoomID = nullIntPtr(roomID)
+	return &m, nil
+}
+
+func (r *MeetingRepository) GetByCourseID(courseID int, termID int) ([]*domain.Meeting, error) {
+	query, args := whereTerm("SELECT "+meetingColumns+" FROM Meetings", "CourseID = ?", courseID, termID)
+	return r.find(query, args...)
+}
+
+func (r *MeetingRepository) GetByRoomID(roomID int, termID int) ([]*domain.Meeting, error) {
+	query, args := whereTerm("SELECT "+meetingColumns+" FROM Meetings", "RoomID = ?", roomID, termID)
+	return r.find(query, args...)
+}
+
+func (r *MeetingRepository) find(query string, args ...interface{}) ([]*domain.Meeting, error) {
+	rows, err := r.db.Query(query+" ORDER BY FIELD(Day, 'monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday'), StartTime", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var meetings []*domain.Meeting
+	for rows.Next() {
+		meeting, err := scanMeeting(rows)
+		if err != nil {
+			return nil, err
+		}
+		meetings = append(meetings, meeting)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return meetings, nil
+}
+
+func (r *MeetingRepository) GetByID(id int) (*domain.Meeting, error) {
+	return scanMeeting(r.db.QueryRow("SELECT "+meetingColumns+" FROM Meetings WHERE ID = ?", id))
+}
+
+func (r *MeetingRepository) Create(meeting *domain.Meeting) error {
+	result, err := r.db.Exec("INSERT INTO Meetings (CourseID, TermID, SectionID, Day, StartTime, EndTime, RoomID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		meeting.CourseID, meeting.TermID, meeting.SectionID, meeting.Day, meeting.StartTime, meeting.EndTime, meeting.RoomID)
+	if err != nil {
+		return err
+	}
+
+	meetingID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	meeting.ID = int(meetingID)
+
+	return nil
+}
+
+func (r *MeetingRepository) Update(meeting *domain.Meeting) error {
+	_, err := r.db.Exec("UPDATE Meetings SET Day = ?, StartTime = ?, EndTime = ?, RoomID = ? WHERE ID = ?",
+		meeting.Day, meeting.StartTime, meeting.EndTime, meeting.RoomID, meeting.ID)
+	return err
+}
+
+func (r *MeetingRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM Meetings WHERE ID = ?", id)
+	return err
+}
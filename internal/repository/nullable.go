@@ -0,0 +1,22 @@
+package repository
+
+import "database/sql"
+
+// nullIntPtr converts a nullable integer column into an optional int.
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullJSON stores an empty JSON document as NULL.
+func nullJSON(data []byte) sql.NullString {
+	return sql.NullString{String: string(data), Valid: len(data) > 0}
+}
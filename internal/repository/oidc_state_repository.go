@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"golang-technical-test/internal/domain"
+	"sync"
+	"time"
+)
+
+// IOIDCStateRepository keeps the pending OIDC logins until the provider
+// redirects back. Each state can be taken only once.
+type IOIDCStateRepository interface {
+	Save(state *domain.OIDCLoginState) error
+	Take(state string) (*domain.OIDCLoginState, error)
+}
+
+// InMemoryOIDCStateRepository keeps the pending logins in process memory.
+type InMemoryOIDCStateRepository struct {
+	mu     sync.Mutex
+	states map[string]*domain.OIDCLoginState
+}
+
+var (
+	oidcStateRepoOnce     sync.Once
+	oidcStateRepoInstance *InMemoryOIDCStateRepository
+)
+
+func NewInMemoryOIDCStateRepository() IOIDCStateRepository {
+	oidcStateRepoOnce.Do(func() {
+		oidcStateRepoInstance = &InMemoryOIDCStateRepository{
+			states: make(map[string]*domain.OIDCLoginState),
+		}
+	})
+	return oidcStateRepoInstance
+}
+
+func (r *InMemoryOIDCStateRepository) Save(state *domain.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Logins that were abandoned are dropped here, so the map doesn't grow.
+	now := time.Now()
+	for key, pending := range r.states {
+		if now.After(pending.ExpiresAt) {
+			delete(r.states, key)
+		}
+	}
+
+	copied := *state
+	r.states[state.State] = &copied
+	return nil
+}
+
+func (r *InMemoryOIDCStateRepository) Take(state string) (*domain.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending, ok := r.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.states, state)
+
+	return pending, nil
+}
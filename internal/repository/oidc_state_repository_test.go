@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"golang-technical-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestInMemoryOIDCStateRepository(t *testing.T) {
+	repo := &InMemoryOIDCStateRepository{states: make(map[string]*domain.OIDCLoginState)}
+	now := time.Now()
+
+	if err := repo.Save(&domain.OIDCLoginState{State: "abandoned", ExpiresAt: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := repo.Save(&domain.OIDCLoginState{State: "pending", Nonce: "n", ExpiresAt: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Saving a login drops the expired ones.
+	if _, ok := repo.states["abandoned"]; ok {
+		t.Error("expired state kept after Save()")
+	}
+
+	pending, err := repo.Take("pending")
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if pending == nil || pending.Nonce != "n" {
+		t.Fatalf("Take() = %+v, want the pending login", pending)
+	}
+
+	// Each state can be taken only once.
+	if again, _ := repo.Take("pending"); again != nil {
+		t.Errorf("Take() of a taken state = %+v, want nil", again)
+	}
+	if unknown, _ := repo.Take("unknown"); unknown != nil {
+		t.Errorf("Take() of an unknown state = %+v, want nil", unknown)
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type IPasswordResetTokenRepository interface {
+	GetByHash(tokenHash string) (*domain.PasswordResetToken, error)
+	Create(token *domain.PasswordResetToken) error
+	MarkUsed(id int, usedAt time.Time) (bool, error)
+	MarkUsedByUserID(userID int, usedAt time.Time) error
+}
+
+type PasswordResetTokenRepository struct {
+	db *database.Database
+}
+
+var (
+	passwordResetTokenRepoOnce     sync.Once
+	passwordResetTokenRepoInstance *PasswordResetTokenRepository
+)
+
+func NewPasswordResetTokenRepository(db *database.Database) IPasswordResetTokenRepository {
+	passwordResetTokenRepoOnce.Do(func() {
+		passwordResetTokenRepoInstance = &PasswordResetTokenRepository{}
+		passwordResetTokenRepoInstance.db = db
+	})
+	return passwordResetTokenRepoInstance
+}
+
+func (r *PasswordResetTokenRepository) GetByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	row := r.db.QueryRow("SELECT ID, UserID, TokenHash, ExpiresAt, UsedAt FROM PasswordResetTokens WHERE TokenHash = ?", tokenHash)
+
+	var t domain.PasswordResetToken
+	var expiresAt, usedAt mysql.NullTime
+	err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &expiresAt, &usedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	t.ExpiresAt = expiresAt.Time
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+
+	return &t, nil
+}
+
+func (r *PasswordResetTokenRepository) Create(token *domain.PasswordResetToken) error {
+	result, err := r.db.Exec("INSERT INTO PasswordResetTokens (UserID, TokenHash, ExpiresAt) VALUES (?, ?, ?)", token.UserID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	tokenID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	token.ID = int(tokenID)
+
+	return nil
+}
+
+// MarkUsed flags the token as consumed. It reports false when the token had
+// already been used, so the same link can't reset the password twice.
+func (r *PasswordResetTokenRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	result, err := r.db.Exec("UPDATE PasswordResetTokens SET UsedAt = ? WHERE ID = ? AND UsedAt IS NULL", usedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// MarkUsedByUserID consumes every pending token of the user.
+func (r *PasswordResetTokenRepository) MarkUsedByUserID(userID int, usedAt time.Time) error {
+	_, err := r.db.Exec("UPDATE PasswordResetTokens SET UsedAt = ? WHERE UserID = ? AND UsedAt IS NULL", usedAt, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IPrerequisiteRepository interface {
+	GetAll() ([]*domain.Prerequisite, error)
+	GetByCourseID(courseID int) ([]*domain.Prerequisite, error)
+	Exists(courseID int, prerequisiteID int) (bool, error)
+	Create(prerequisite *domain.Prerequisite) error
+	Delete(courseID int, prerequisiteID int) error
+}
+
+type PrerequisiteRepository struct {
+	db *database.Database
+}
+
+var (
+	prerequisiteRepoOnce     sync.Once
+	prerequisiteRepoInstance *PrerequisiteRepository
+)
+
+func NewPrerequisiteRepository(db *database.Database) IPrerequisiteRepository {
+	prerequisiteRepoOnce.Do(func() {
+		prerequisiteRepoInstance = &PrerequisiteRepository{}
+		prerequisiteRepoInstance.db = db
+	})
+	return prerequisiteRepoInstance
+}
+
+func (r *PrerequisiteRepository) GetAll() ([]*domain.Prerequisite, error) {
+	return r.find("SELECT CourseID, PrerequisiteID, MinGrade FROM Prerequisites")
+}
+
+func (r *PrerequisiteRepository) GetByCourseID(courseID int) ([]*domain.Prerequisite, error) {
+	return r.find("SELECT CourseID, PrerequisiteID, MinGrade FROM Prerequisites WHERE CourseID = ?", courseID)
+}
+
+func (r *PrerequisiteRepository) find(query string, args ...interface{}) ([]*domain.Prerequisite, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []*domain.Prerequisite
+	for rows.Next() {
+		var p domain.Prerequisite
+		var minGrade sql.NullFloat64
+		if err := rows.Scan(&p.CourseID, &p.PrerequisiteID, &minGrade); err != nil {
+			return nil, err
+		}
+		if minGrade.Valid {
+			p.MinGrade = &minGrade.Float64
+		}
+		prerequisites = append(prerequisites, &p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prerequisites, nil
+}
+
+func (r *PrerequisiteRepository) Exists(courseID int, prerequisiteID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM Prerequisites WHERE CourseID = ? AND PrerequisiteID = ?)", courseID, prerequisiteID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *PrerequisiteRepository) Create(prerequisite *domain.Prerequisite) error {
+	_, err := r.db.Exec("INSERT INTO Prerequisites (CourseID, PrerequisiteID, MinGrade) VALUES (?, ?, ?)", prerequisite.CourseID, prerequisite.PrerequisiteID, prerequisite.MinGrade)
+	return err
+}
+
+func (r *PrerequisiteRepository) Delete(courseID int, prerequisiteID int) error {
+	_, err := r.db.Exec("DELETE FROM Prerequisites WHERE CourseID = ? AND PrerequisiteID = ?", courseID, prerequisiteID)
+	return err
+}
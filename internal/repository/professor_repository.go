@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
@@ -13,6 +14,7 @@ type IProfessorRepository interface {
 	Create(professor *domain.Professor) error
 	Update(professor *domain.Professor) error
 	Delete(id int) error
+	WithTx(tx *database.Database) IProfessorRepository
 }
 
 type ProfessorRepository struct {
@@ -32,19 +34,26 @@ func NewProfessorRepository(db *database.Database) IProfessorRepository {
 	return professorRepoInstance
 }
 
+// WithTx returns the repository running its statements in tx.
+func (r *ProfessorRepository) WithTx(tx *database.Database) IProfessorRepository {
+	return &ProfessorRepository{db: tx}
+}
+
 func (r *ProfessorRepository) GetAll() ([]*domain.Professor, error) {
 	var professors []*domain.Professor
-	rows, err := r.db.Query("SELECT ID, Name, Lastname, Email, Specialization FROM Professors")
+	rows, err := r.db.Query("SELECT ID, Name, Lastname, Email, Specialization, DepartmentID FROM Professors")
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var professor domain.Professor
-		err := rows.Scan(&professor.ID, &professor.Name, &professor.LastName, &professor.Email, &professor.Specialization)
+		var departmentID sql.NullInt64
+		err := rows.Scan(&professor.ID, &professor.Name, &professor.LastName, &professor.Email, &professor.Specialization, &departmentID)
 		if err != nil {
 			return nil, err
 		}
+		professor.DepartmentID = nullIntPtr(departmentID)
 		professors = append(professors, &professor)
 	}
 	return professors, nil
@@ -52,15 +61,17 @@ func (r *ProfessorRepository) GetAll() ([]*domain.Professor, error) {
 
 func (r *ProfessorRepository) GetByID(id int) (*domain.Professor, error) {
 	var professor domain.Professor
-	err := r.db.QueryRow("SELECT ID, Name, Lastname, Email, Specialization FROM Professors WHERE ID = ?", id).Scan(&professor.ID, &professor.Name, &professor.LastName, &professor.Email, &professor.Specialization)
+	var departmentID sql.NullInt64
+	err := r.db.QueryRow("SELECT ID, Name, Lastname, Email, Specialization, DepartmentID FROM Professors WHERE ID = ?", id).Scan(&professor.ID, &professor.Name, &professor.LastName, &professor.Email, &professor.Specialization, &departmentID)
 	if err != nil {
 		return nil, err
 	}
+	professor.DepartmentID = nullIntPtr(departmentID)
 	return &professor, nil
 }
 
 func (r *ProfessorRepository) Create(professor *domain.Professor) error {
-	result, err := r.db.Exec("INSERT INTO Professors (Name, Lastname, Email, Specialization) VALUES (?, ?, ?, ?)", professor.Name, professor.LastName, professor.Email, professor.Specialization)
+	result, err := r.db.Exec("INSERT INTO Professors (Name, Lastname, Email, Specialization, DepartmentID) VALUES (?, ?, ?, ?, ?)", professor.Name, professor.LastName, professor.Email, professor.Specialization, professor.DepartmentID)
 	if err != nil {
 		return err
 	}
@@ -75,7 +86,7 @@ func (r *ProfessorRepository) Create(professor *domain.Professor) error {
 }
 
 func (r *ProfessorRepository) Update(professor *domain.Professor) error {
-	_, err := r.db.Exec("UPDATE Professors SET Name = ?, Lastname = ?, Email = ?, Specialization = ? WHERE ID = ?", professor.Name, professor.LastName, professor.Email, professor.Specialization, professor.ID)
+	_, err := r.db.Exec("UPDATE Professors SET Name = ?, Lastname = ?, Email = ?, Specialization = ?, DepartmentID = ? WHERE ID = ?", professor.Name, professor.LastName, professor.Email, professor.Specialization, professor.DepartmentID, professor.ID)
 	if err != nil {
 		return err
 	}
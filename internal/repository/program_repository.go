@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IProgramRepository interface {
+	GetAll() ([]*domain.Program, error)
+	GetByID(id int) (*domain.Program, error)
+	Create(program *domain.Program) error
+	Update(program *domain.Program) error
+	Delete(id int) error
+	IsUsed(id int) (bool, error)
+	WithTx(tx *database.Database) IProgramRepository
+}
+
+type ProgramRepository struct {
+	db *database.Database
+}
+
+var (
+	programRepoOnce     sync.Once
+	programRepoInstance *ProgramRepository
+)
+
+func NewProgramRepository(db *database.Database) IProgramRepository {
+	programRepoOnce.Do(func() {
+		programRepoInstance = &ProgramRepository{}
+		programRepoInstance.db = db
+	})
+	return programRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *ProgramRepository) WithTx(tx *database.Database) IProgramRepository {
+	return &ProgramRepository{db: tx}
+}
+
+func (r *ProgramRepository) GetAll() ([]*domain.Program, error) {
+	rows, err := r.db.Query("SELECT ID, DepartmentID, Name, MinCredits FROM Programs ORDER BY Name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var programs []*domain.Program
+	for rows.Next() {
+		var p domain.Program
+		if err := rows.Scan(&p.ID, &p.DepartmentID, &p.Name, &p.MinCredits); err != nil {
+			return nil, err
+		}
+		programs = append(programs, &p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, program := range programs {
+		if program.CourseSets, err = r.courseSets(program.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return programs, nil
+}
+
+func (r *ProgramRepository) GetByID(id int) (*domain.Program, error) {
+	var p domain.Program
+	err := r.db.QueryRow("SELECT ID, DepartmentID, Name, MinCredits FROM Programs WHERE ID = ?", id).Scan(&p.ID, &p.DepartmentID, &p.Name, &p.MinCredits)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.CourseSets, err = r.courseSets(p.ID); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// courseSets returns the course sets of a program in the order they were
+// given.
+func (r *ProgramRepository) courseSets(programID int) ([]domain.CourseSet, error) {
+	rows, err := r.db.Query(`SELECT s.ID, s.Name, s.Kind, s.MinCredits, c.CourseID
+		FROM ProgramCourseSets s INNER JOIN ProgramCourseSetCourses c ON c.SetID = s.ID
+		WHERE s.ProgramID = ? ORDER BY s.ID, c.CourseID`, programID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sets := make([]domain.CourseSet, 0)
+	lastID := 0
+	for rows.Next() {
+		var setID, courseID int
+		var set domain.CourseSet
+		if err := rows.Scan(&setID, &set.Name, &set.Kind, &set.MinCredits, &courseID); err != nil {
+			return nil, err
+		}
+		if setID != lastID {
+			sets = append(sets, set)
+			lastID = setID
+		}
+		last := &sets[len(sets)-1]
+		last.CourseIDs = append(last.CourseIDs, courseID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+func (r *ProgramRepository) Create(program *domain.Program) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("INSERT INTO Programs (DepartmentID, Name, MinCredits) VALUES (?, ?, ?)", program.DepartmentID, program.Name, program.MinCredits)
+	if err != nil {
+		return err
+	}
+
+	programID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if err := insertCourseSets(tx, int(programID), program.CourseSets); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	program.ID = int(programID)
+
+	return nil
+}
+
+// Update changes the program and replaces its course sets.
+func (r *ProgramRepository) Update(program *domain.Program) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE Programs SET DepartmentID = ?, Name = ?, MinCredits = ? WHERE ID = ?", program.DepartmentID, program.Name, program.MinCredits, program.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteCourseSets(tx, program.ID); err != nil {
+		return err
+	}
+	if err := insertCourseSets(tx, program.ID, program.CourseSets); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertCourseSets(tx *database.Tx, programID int, sets []domain.CourseSet) error {
+	for _, set := range sets {
+		result, err := tx.Exec("INSERT INTO ProgramCourseSets (ProgramID, Name, Kind, MinCredits) VALUES (?, ?, ?, ?)", programID, set.Name, set.Kind, set.MinCredits)
+		if err != nil {
+			return err
+		}
+		setID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		for _, courseID := range set.CourseIDs {
+			if _, err := tx.Exec("INSERT INTO ProgramCourseSetCourses (SetID, CourseID) VALUES (?, ?)", setID, courseID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteCourseSets(tx *database.Tx, programID int) error {
+	_, err := tx.Exec("DELETE c FROM ProgramCourseSetCourses c INNER JOIN ProgramCourseSets s ON s.ID = c.SetID WHERE s.ProgramID = ?", programID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM ProgramCourseSets WHERE ProgramID = ?", programID)
+	return err
+}
+
+func (r *ProgramRepository) Delete(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := deleteCourseSets(tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM Programs WHERE ID = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// IsUsed reports whether a student is assigned to the program.
+func (r *ProgramRepository) IsUsed(id int) (bool, error) {
+	var used bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM Students WHERE ProgramID = ?)", id).Scan(&used)
+	return used, err
+}
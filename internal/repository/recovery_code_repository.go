@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"sync"
+	"time"
+)
+
+type IRecoveryCodeRepository interface {
+	Replace(userID int, codeHashes []string) error
+	Use(userID int, codeHash string, usedAt time.Time) (bool, error)
+	CountUnused(userID int) (int, error)
+	DeleteByUserID(userID int) error
+}
+
+type RecoveryCodeRepository struct {
+	db *database.Database
+}
+
+var (
+	recoveryCodeRepoOnce     sync.Once
+	recoveryCodeRepoInstance *RecoveryCodeRepository
+)
+
+func NewRecoveryCodeRepository(db *database.Database) IRecoveryCodeRepository {
+	recoveryCodeRepoOnce.Do(func() {
+		recoveryCodeRepoInstance = &RecoveryCodeRepository{}
+		recoveryCodeRepoInstance.db = db
+	})
+	return recoveryCodeRepoInstance
+}
+
+// Replace discards the user's recovery codes and stores the new ones.
+func (r *RecoveryCodeRepository) Replace(userID int, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM RecoveryCodes WHERE UserID = ?", userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.Exec("INSERT INTO RecoveryCodes (UserID, CodeHash) VALUES (?, ?)", userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Use consumes an unused recovery code. It reports false when the user has
+// no such code or it was already used.
+func (r *RecoveryCodeRepository) Use(userID int, codeHash string, usedAt time.Time) (bool, error) {
+	result, err := r.db.Exec("UPDATE RecoveryCodes SET UsedAt = ? WHERE UserID = ? AND CodeHash = ? AND UsedAt IS NULL", usedAt, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *RecoveryCodeRepository) CountUnused(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM RecoveryCodes WHERE UserID = ? AND UsedAt IS NULL", userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *RecoveryCodeRepository) DeleteByUserID(userID int) error {
+	_, err := r.db.Exec("DELETE FROM RecoveryCodes WHERE UserID = ?", userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
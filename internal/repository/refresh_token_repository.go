@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type IRefreshTokenRepository interface {
+	GetByHash(tokenHash string) (*domain.RefreshToken, error)
+	Create(token *domain.RefreshToken) error
+	MarkUsed(id int, usedAt time.Time) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeByUserID(userID int) error
+}
+
+type RefreshTokenRepository struct {
+	db *database.Database
+}
+
+var (
+	refreshTokenRepoOnce     sync.Once
+	refreshTokenRepoInstance *RefreshTokenRepository
+)
+
+func NewRefreshTokenRepository(db *database.Database) IRefreshTokenRepository {
+	refreshTokenRepoOnce.Do(func() {
+		refreshTokenRepoInstance = &RefreshTokenRepository{}
+		refreshTokenRepoInstance.db = db
+	})
+	return refreshTokenRepoInstance
+}
+
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*domain.RefreshToken, error) {
+	row := r.db.QueryRow("SELECT ID, UserID, FamilyID, TokenHash, ExpiresAt, UsedAt, Revoked FROM RefreshTokens WHERE TokenHash = ?", tokenHash)
+
+	var t domain.RefreshToken
+	var expiresAt, usedAt mysql.NullTime
+	err := row.Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &expiresAt, &usedAt, &t.Revoked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	t.ExpiresAt = expiresAt.Time
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+
+	return &t, nil
+}
+
+func (r *RefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	result, err := r.db.Exec("INSERT INTO RefreshTokens (UserID, FamilyID, TokenHash, ExpiresAt) VALUES (?, ?, ?, ?)", token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	tokenID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	token.ID = int(tokenID)
+
+	return nil
+}
+
+// MarkUsed flags the token as consumed. It reports false when the token had
+// already been used or revoked, which lets concurrent refreshes race safely.
+func (r *RefreshTokenRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	result, err := r.db.Exec("UPDATE RefreshTokens SET UsedAt = ? WHERE ID = ? AND UsedAt IS NULL AND Revoked = FALSE", usedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	_, err := r.db.Exec("UPDATE RefreshTokens SET Revoked = TRUE WHERE FamilyID = ?", familyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUserID(userID int) error {
+	_, err := r.db.Exec("UPDATE RefreshTokens SET Revoked = TRUE WHERE UserID = ?", userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"sync"
+	"time"
+)
+
+// IRevokedTokenRepository keeps the access tokens that must be rejected
+// before they expire, either one by one (by JWT ID) or every token issued
+// to a user up to a point in time. The cut-off is inclusive: a token issued
+// at exactly the time passed to RevokeUser is revoked.
+type IRevokedTokenRepository interface {
+	RevokeToken(jti string, expiresAt time.Time) error
+	RevokeUser(userID int, before time.Time) error
+	IsRevoked(jti string, userID int, issuedAt time.Time) (bool, error)
+}
+
+type RevokedTokenRepository struct {
+	db *database.Database
+}
+
+var (
+	revokedTokenRepoOnce     sync.Once
+	revokedTokenRepoInstance *RevokedTokenRepository
+)
+
+func NewRevokedTokenRepository(db *database.Database) IRevokedTokenRepository {
+	revokedTokenRepoOnce.Do(func() {
+		revokedTokenRepoInstance = &RevokedTokenRepository{}
+		revokedTokenRepoInstance.db = db
+	})
+	return revokedTokenRepoInstance
+}
+
+func (r *RevokedTokenRepository) RevokeToken(jti string, expiresAt time.Time) error {
+	_, err := r.db.Exec("INSERT IGNORE INTO RevokedTokens (JTI, ExpiresAt) VALUES (?, ?)", jti, expiresAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	// Entries are only useful until the token would have expired anyway.
+	_, err = r.db.Exec("DELETE FROM RevokedTokens WHERE ExpiresAt < ?", time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RevokedTokenRepository) RevokeUser(userID int, before time.Time) error {
+	_, err := r.db.Exec("INSERT INTO UserTokenRevocations (UserID, RevokedBefore) VALUES (?, ?) ON DUPLICATE KEY UPDATE RevokedBefore = VALUES(RevokedBefore)", userID, before.UTC())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RevokedTokenRepository) IsRevoked(jti string, userID int, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM RevokedTokens WHERE JTI = ?) OR EXISTS(SELECT 1 FROM UserTokenRevocations WHERE UserID = ? AND RevokedBefore >= ?)", jti, userID, issuedAt.UTC()).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// InMemoryRevokedTokenRepository is a process-local IRevokedTokenRepository.
+// It suits a single instance deployment; revocations are lost on restart.
+type InMemoryRevokedTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time
+	users  map[int]time.Time
+}
+
+func NewInMemoryRevokedTokenRepository() IRevokedTokenRepository {
+	return &InMemoryRevokedTokenRepository{
+		tokens: make(map[string]time.Time),
+		users:  make(map[int]time.Time),
+	}
+}
+
+func (r *InMemoryRevokedTokenRepository) RevokeToken(jti string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range r.tokens {
+		if exp.Before(now) {
+			delete(r.tokens, id)
+		}
+	}
+	r.tokens[jti] = expiresAt
+
+	return nil
+}
+
+func (r *InMemoryRevokedTokenRepository) RevokeUser(userID int, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[userID] = before
+
+	return nil
+}
+
+func (r *InMemoryRevokedTokenRepository) IsRevoked(jti string, userID int, issuedAt time.Time) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.tokens[jti]; ok {
+		return true, nil
+	}
+	if before, ok := r.users[userID]; ok && !issuedAt.After(before) {
+		return true, nil
+	}
+
+	return false, nil
+}
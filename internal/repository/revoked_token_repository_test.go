@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInMemoryRevokedTokenRepositoryRevokeUser(t *testing.T) {
+	repo := NewInMemoryRevokedTokenRepository()
+	cutoff := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := repo.RevokeUser(1, cutoff); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userID   int
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"issued the second before", 1, cutoff.Add(-time.Second), true},
+		{"issued in the cut-off second", 1, cutoff, true},
+		{"issued the second after", 1, cutoff.Add(time.Second), false},
+		{"another user", 2, cutoff, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := repo.IsRevoked("jti", tt.userID, tt.issuedAt)
+			if err != nil {
+				t.Fatalf("IsRevoked() error = %v", err)
+			}
+			if revoked != tt.revoked {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.revoked)
+			}
+		})
+	}
+}
+
+func TestInMemoryRevokedTokenRepositoryRevokeToken(t *testing.T) {
+	repo := NewInMemoryRevokedTokenRepository()
+	if err := repo.RevokeToken("revoked", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+
+	if revoked, _ := repo.IsRevoked("revoked", 1, time.Now()); !revoked {
+		t.Error("IsRevoked() = false for a revoked token, want true")
+	}
+	if revoked, _ := repo.IsRevoked("other", 1, time.Now()); revoked {
+		t.Error("IsRevoked() = true for another token, want false")
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"strings"
+	"sync"
+)
+
+type IRoomRepository interface {
+	GetAll() ([]*domain.Room, error)
+	GetByID(id int) (*domain.Room, error)
+	Lock(id int) (*domain.Room, error)
+	Create(room *domain.Room) error
+	Update(room *domain.Room) error
+	Delete(id int) error
+	IsUsed(id int) (bool, error)
+	WithTx(tx *database.Database) IRoomRepository
+}
+
+type RoomRepository struct {
+	db *database.Database
+}
+
+var (
+	roomRepoOnce     sync.Once
+	roomRepoInstance *RoomRepository
+)
+
+func NewRoomRepository(db *database.Database) IRoomRepository {
+	roomRepoOnce.Do(func() {
+		roomRepoInstance = &RoomRepository{}
+		roomRepoInstance.db = db
+	})
+	return roomRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *RoomRepository) WithTx(tx *database.Database) IRoomRepository {
+	return &RoomRepository{db: tx}
+}
+
+const roomColumns = "ID, Name, Building, Capacity, Features"
+
+func scanRoom(row rowScanner) (*domain.Room, error) {
+	var room domain.Room
+	var features string
+	err := row.Scan(&room.ID, &room.Name, &room.Building, &room.Capacity, &features)
+	if err != nil {
+		return nil, err
+	}
+	room.Features = []string{}
+	if features != "" {
+		room.Features = strings.Split(features, ",")
+	}
+	return &room, nil
+}
+
+func (r *RoomRepository) GetAll() ([]*domain.Room, error) {
+	rows, err := r.db.Query("SELECT " + roomColumns + " FROM Rooms ORDER BY Building, Name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []*domain.Room
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+func (r *RoomRepository) GetByID(id int) (*domain.Room, error) {
+	return scanRoom(r.db.QueryRow("SELECT "+roomColumns+" FROM Rooms WHERE ID = ?", id))
+}
+
+// Lock returns the room and locks its row until the transaction the
+// repository is bound to ends, so meetings booking the room are checked
+// and written one at a time.
+func (r *RoomRepository) Lock(id int) (*domain.Room, error) {
+	return scanRoom(r.db.QueryRow("SELECT "+roomColumns+" FROM Rooms WHERE ID = ? FOR UPDATE", id))
+}
+
+func (r *RoomRepository) Create(room *domain.Room) error {
+	result, err := r.db.Exec("INSERT INTO Rooms (Name, Building, Capacity, Features) VALUES (?, ?, ?, ?)",
+		room.Name, room.Building, room.Capacity, strings.Join(room.Features, ","))
+	if err != nil {
+		return err
+	}
+
+	roomID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	room.ID = int(roomID)
+
+	return nil
+}
+
+func (r *RoomRepository) Update(room *domain.Room) error {
+	_, err := r.db.Exec("UPDATE Rooms SET Name = ?, Building = ?, Capacity = ?, Features = ? WHERE ID = ?",
+		room.Name, room.Building, room.Capacity, strings.Join(room.Features, ","), room.ID)
+	return err
+}
+
+func (r *RoomRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM Rooms WHERE ID = ?", id)
+	return err
+}
+
+// IsUsed reports whether any meeting is held in the room.
+func (r *RoomRepository) IsUsed(id int) (bool, error) {
+	var used bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM Meetings WHERE RoomID = ?)", id).Scan(&used)
+	return used, err
+}
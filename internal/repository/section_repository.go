@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type ISectionRepository interface {
+	// GetByCourseID lists the sections of a course; a termID of 0 means
+	// every term.
+	GetByCourseID(courseID int, termID int) ([]*domain.Section, error)
+	GetByID(id int) (*domain.Section, error)
+	Create(section *domain.Section) error
+	Update(section *domain.Section) error
+	Delete(id int) error
+	WithTx(tx *database.Database) ISectionRepository
+}
+
+type SectionRepository struct {
+	db *database.Database
+}
+
+var (
+	sectionRepoOnce     sync.Once
+	sectionRepoInstance *SectionRepository
+)
+
+func NewSectionRepository(db *database.Database) ISectionRepository {
+	sectionRepoOnce.Do(func() {
+		sectionRepoInstance = &SectionRepository{}
+		sectionRepoInstance.db = db
+	})
+	return sectionRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *SectionRepository) WithTx(tx *database.Database) ISectionRepository {
+	return &SectionRepository{db: tx}
+}
+
+const sectionColumns = "ID, CourseID, TermID, Name, Capacity, Waitlist"
+
+func scanSection(row rowScanner) (*domain.Section, error) {
+	var s domain.Section
+	err := row.Scan(&s.ID, &s.CourseID, &s.TermID, &s.Name, &s.Capacity, &s.Waitlist)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SectionRepository) GetByCourseID(courseID int, termID int) ([]*domain.Section, error) {
+	query, args := whereTerm("SELECT "+sectionColumns+" FROM Sections", "CourseID = ?", courseID, termID)
+	rows, err := r.db.Query(query+" ORDER BY Name", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []*domain.Section
+	for rows.Next() {
+		section, err := scanSection(rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+func (r *SectionRepository) GetByID(id int) (*domain.Section, error) {
+	return scanSection(r.db.QueryRow("SELECT "+sectionColumns+" FROM Sections WHERE ID = ?", id))
+}
+
+func (r *SectionRepository) Create(section *domain.Section) error {
+	result, err := r.db.Exec("INSERT INTO Sections (CourseID, TermID, Name, Capacity, Waitlist) VALUES (?, ?, ?, ?, ?)",
+		section.CourseID, section.TermID, section.Name, section.Capacity, section.Waitlist)
+	if err != nil {
+		return err
+	}
+
+	sectionID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	section.ID = int(sectionID)
+
+	return nil
+}
+
+// Update changes the name, capacity and waitlist option of a section. Its
+// course and term can't change.
+func (r *SectionRepository) Update(section *domain.Section) error {
+	_, err := r.db.Exec("UPDATE Sections SET Name = ?, Capacity = ?, Waitlist = ? WHERE ID = ?", section.Name, section.Capacity, section.Waitlist, section.ID)
+	return err
+}
+
+func (r *SectionRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM Sections WHERE ID = ?", id)
+	return err
+}
@@ -5,6 +5,8 @@ import (
 	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"sync"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 type IStudentRepository interface {
@@ -13,6 +15,10 @@ type IStudentRepository interface {
 	Create(student *domain.Student) error
 	Update(student *domain.Student) error
 	Delete(id int) error
+	SetProgram(studentID int, programID *int) error
+	ChangeStatus(change *domain.StudentStatusChange) error
+	GetStatusHistory(studentID int) ([]*domain.StudentStatusChange, error)
+	WithTx(tx *database.Database) IStudentRepository
 }
 
 type StudentRepository struct {
@@ -32,8 +38,13 @@ func NewStudentRepository(db *database.Database) IStudentRepository {
 	return studentsRepoInstance
 }
 
+// WithTx returns the repository running its statements in tx.
+func (r *StudentRepository) WithTx(tx *database.Database) IStudentRepository {
+	return &StudentRepository{db: tx}
+}
+
 func (r *StudentRepository) GetAll() ([]*domain.Student, error) {
-	rows, err := r.db.Query("SELECT ID, Name, Lastname, DateOfBirth, Address, Email FROM Students")
+	rows, err := r.db.Query("SELECT ID, Name, Lastname, DateOfBirth, Address, Email, ProgramID, Status FROM Students")
 	if err != nil {
 		return nil, err
 	}
@@ -42,10 +53,12 @@ func (r *StudentRepository) GetAll() ([]*domain.Student, error) {
 	var students []*domain.Student
 	for rows.Next() {
 		var s domain.Student
-		err = rows.Scan(&s.ID, &s.Name, &s.LastName, &s.DateOfBirth, &s.Address, &s.Email)
+		var programID sql.NullInt64
+		err = rows.Scan(&s.ID, &s.Name, &s.LastName, &s.DateOfBirth, &s.Address, &s.Email, &programID, &s.Status)
 		if err != nil {
 			return nil, err
 		}
+		s.ProgramID = nullIntPtr(programID)
 		students = append(students, &s)
 	}
 
@@ -57,10 +70,11 @@ func (r *StudentRepository) GetAll() ([]*domain.Student, error) {
 }
 
 func (r *StudentRepository) GetByID(id int) (*domain.Student, error) {
-	row := r.db.QueryRow("SELECT ID, Name, Lastname, DateOfBirth, Address, Email FROM Students WHERE ID = ?", id)
+	row := r.db.QueryRow("SELECT ID, Name, Lastname, DateOfBirth, Address, Email, ProgramID, Status FROM Students WHERE ID = ?", id)
 
 	var s domain.Student
-	err := row.Scan(&s.ID, &s.Name, &s.LastName, &s.DateOfBirth, &s.Address, &s.Email)
+	var programID sql.NullInt64
+	err := row.Scan(&s.ID, &s.Name, &s.LastName, &s.DateOfBirth, &s.Address, &s.Email, &programID, &s.Status)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// There were no rows, but otherwise no error occurred
@@ -68,18 +82,19 @@ func (r *StudentRepository) GetByID(id int) (*domain.Student, error) {
 		}
 		return nil, err
 	}
+	s.ProgramID = nullIntPtr(programID)
 
 	return &s, nil
 }
 
 func (r *StudentRepository) Create(student *domain.Student) error {
-	stmt, err := r.db.Prepare("INSERT INTO Students (Name, Lastname, DateOfBirth, Address, Email) VALUES (?, ?, ?, ?, ?)")
+	stmt, err := r.db.Prepare("INSERT INTO Students (Name, Lastname, DateOfBirth, Address, Email, Status) VALUES (?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(student.Name, student.LastName, student.DateOfBirth, student.Address, student.Email)
+	result, err := stmt.Exec(student.Name, student.LastName, student.DateOfBirth, student.Address, student.Email, student.Status)
 	if err != nil {
 		return err
 	}
@@ -108,17 +123,92 @@ func (r *StudentRepository) Update(student *domain.Student) error {
 	return nil
 }
 
+// Delete removes the student along with their status history.
 func (r *StudentRepository) Delete(id int) error {
-	stmt, err := r.db.Prepare("DELETE FROM Students WHERE ID = ?")
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM StudentStatusChanges WHERE StudentID = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM Students WHERE ID = ?", id); err != nil {
+		return err
+	}
 
-	_, err = stmt.Exec(id)
+	return tx.Commit()
+}
+
+// SetProgram assigns the student to a program, or to none when programID
+// is nil.
+func (r *StudentRepository) SetProgram(studentID int, programID *int) error {
+	_, err := r.db.Exec("UPDATE Students SET ProgramID = ? WHERE ID = ?", programID, studentID)
+	return err
+}
+
+// ChangeStatus moves the student from change.FromStatus to change.ToStatus
+// and records the change. It returns domain.ErrConflict when the student's
+// status is no longer FromStatus.
+func (r *StudentRepository) ChangeStatus(change *domain.StudentStatusChange) error {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	return nil
+	result, err := tx.Exec("UPDATE Students SET Status = ? WHERE ID = ? AND Status = ?", change.ToStatus, change.StudentID, change.FromStatus)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return domain.ErrConflict
+	}
+
+	result, err = tx.Exec("INSERT INTO StudentStatusChanges (StudentID, FromStatus, ToStatus, Reason, ActorUserID, ActorUsername, ChangedAt) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		change.StudentID, change.FromStatus, change.ToStatus, change.Reason, change.ActorUserID, change.ActorUsername, change.ChangedAt)
+	if err != nil {
+		return err
+	}
+	changeID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	change.ID = int(changeID)
+
+	return tx.Commit()
+}
+
+// GetStatusHistory returns the status changes of the student, oldest first.
+func (r *StudentRepository) GetStatusHistory(studentID int) ([]*domain.StudentStatusChange, error) {
+	rows, err := r.db.Query("SELECT ID, StudentID, FromStatus, ToStatus, Reason, ActorUserID, ActorUsername, ChangedAt FROM StudentStatusChanges WHERE StudentID = ? ORDER BY ChangedAt, ID", studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*domain.StudentStatusChange{}
+	for rows.Next() {
+		var c domain.StudentStatusChange
+		var actorUserID sql.NullInt64
+		var changedAt mysql.NullTime
+		err = rows.Scan(&c.ID, &c.StudentID, &c.FromStatus, &c.ToStatus, &c.Reason, &actorUserID, &c.ActorUsername, &changedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.ActorUserID = nullIntPtr(actorUserID)
+		c.ChangedAt = changedAt.Time
+		changes = append(changes, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
 }
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type ITeachingAssignmentRepository interface {
+	GetCoursesByProfessorID(professorID int) ([]*domain.Course, error)
+	Exists(professorID, courseID int) (bool, error)
+	Create(assignment *domain.TeachingAssignment) error
+	Delete(professorID, courseID int) error
+}
+
+type TeachingAssignmentRepository struct {
+	db *database.Database
+}
+
+var (
+	teachingAssignmentRepoOnce     sync.Once
+	teachingAssignmentRepoInstance *TeachingAssignmentRepository
+)
+
+func NewTeachingAssignmentRepository(db *database.Database) ITeachingAssignmentRepository {
+	teachingAssignmentRepoOnce.Do(func() {
+		teachingAssignmentRepoInstance = &TeachingAssignmentRepository{}
+		teachingAssignmentRepoInstance.db = db
+	})
+	return teachingAssignmentRepoInstance
+}
+
+func (r *TeachingAssignmentRepository) GetCoursesByProfessorID(professorID int) ([]*domain.Course, error) {
+	rows, err := r.db.Query("SELECT c.ID, c.Name, c.Description, c.Credits, c.GradingScaleID FROM Courses c INNER JOIN TeachingAssignments ta ON ta.CourseID = c.ID WHERE ta.ProfessorID = ?", professorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	courses := make([]*domain.Course, 0)
+	for rows.Next() {
+		course, err := scanCourse(rows)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, course)
+	}
+
+	return courses, nil
+}
+
+func (r *TeachingAssignmentRepository) Exists(professorID, courseID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM TeachingAssignments WHERE ProfessorID = ? AND CourseID = ?)", professorID, courseID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *TeachingAssignmentRepository) Create(assignment *domain.TeachingAssignment) error {
+	result, err := r.db.Exec("INSERT INTO TeachingAssignments (ProfessorID, CourseID) VALUES (?, ?)", assignment.ProfessorID, assignment.CourseID)
+	if err != nil {
+		return err
+	}
+
+	assignmentID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	assignment.ID = int(assignmentID)
+
+	return nil
+}
+
+func (r *TeachingAssignmentRepository) Delete(professorID, courseID int) error {
+	_, err := r.db.Exec("DELETE FROM TeachingAssignments WHERE ProfessorID = ? AND CourseID = ?", professorID, courseID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
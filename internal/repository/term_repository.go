@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"strings"
+	"sync"
+)
+
+type ITermRepository interface {
+	GetAll() ([]*domain.Term, error)
+	GetByID(id int) (*domain.Term, error)
+	GetActive() (*domain.Term, error)
+	Create(term *domain.Term) error
+	Update(term *domain.Term) error
+	Delete(id int) error
+	Activate(id int) error
+	Deactivate(id int) error
+	WithTx(tx *database.Database) ITermRepository
+}
+
+type TermRepository struct {
+	db *database.Database
+}
+
+var (
+	termRepoOnce     sync.Once
+	termRepoInstance *TermRepository
+)
+
+func NewTermRepository(db *database.Database) ITermRepository {
+	termRepoOnce.Do(func() {
+		termRepoInstance = &TermRepository{}
+		termRepoInstance.db = db
+	})
+	return termRepoInstance
+}
+
+// WithTx returns the repository running its statements in tx.
+func (r *TermRepository) WithTx(tx *database.Database) ITermRepository {
+	return &TermRepository{db: tx}
+}
+
+const termColumns = "ID, Name, StartDate, EndDate, Active"
+
+func scanTerm(row rowScanner) (*domain.Term, error) {
+	var t domain.Term
+	err := row.Scan(&t.ID, &t.Name, &t.StartDate, &t.EndDate, &t.Active)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TermRepository) GetAll() ([]*domain.Term, error) {
+	rows, err := r.db.Query("SELECT " + termColumns + " FROM Terms ORDER BY StartDate")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var terms []*domain.Term
+	for rows.Next() {
+		term, err := scanTerm(rows)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+func (r *TermRepository) GetByID(id int) (*domain.Term, error) {
+	return scanTerm(r.db.QueryRow("SELECT "+termColumns+" FROM Terms WHERE ID = ?", id))
+}
+
+// GetActive returns the active term, or nil when no term is active.
+func (r *TermRepository) GetActive() (*domain.Term, error) {
+	term, err := scanTerm(r.db.QueryRow("SELECT " + termColumns + " FROM Terms WHERE Active = TRUE LIMIT 1"))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return term, err
+}
+
+func (r *TermRepository) Create(term *domain.Term) error {
+	result, err := r.db.Exec("INSERT INTO Terms (Name, StartDate, EndDate, Active) VALUES (?, ?, ?, FALSE)", term.Name, term.StartDate, term.EndDate)
+	if err != nil {
+		return err
+	}
+
+	termID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	term.ID = int(termID)
+
+	return nil
+}
+
+// Update changes the name and dates of a term. The active flag is only
+// changed through Activate and Deactivate.
+func (r *TermRepository) Update(term *domain.Term) error {
+	_, err := r.db.Exec("UPDATE Terms SET Name = ?, StartDate = ?, EndDate = ? WHERE ID = ?", term.Name, term.StartDate, term.EndDate, term.ID)
+	return err
+}
+
+func (r *TermRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM Terms WHERE ID = ?", id)
+	return err
+}
+
+// Activate makes the term the active one and deactivates every other term
+// in the same statement.
+func (r *TermRepository) Activate(id int) error {
+	_, err := r.db.Exec("UPDATE Terms SET Active = (ID = ?)", id)
+	return err
+}
+
+func (r *TermRepository) Deactivate(id int) error {
+	_, err := r.db.Exec("UPDATE Terms SET Active = FALSE WHERE ID = ?", id)
+	return err
+}
+
+// whereTerm appends to query a WHERE clause with condition, which takes arg,
+// and a TermID filter when termID is not 0. An empty condition is left out.
+func whereTerm(query, condition string, arg interface{}, termID int) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+	if termID != 0 {
+		conditions = append(conditions, "TermID = ?")
+		args = append(args, termID)
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query, args
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type ITOTPRepository interface {
+	GetByUserID(userID int) (*domain.TOTPCredential, error)
+	Save(credential *domain.TOTPCredential) error
+	Enable(userID int) error
+	UpdateLastStep(userID int, step int64) (bool, error)
+	Delete(userID int) error
+}
+
+type TOTPRepository struct {
+	db *database.Database
+}
+
+var (
+	totpRepoOnce     sync.Once
+	totpRepoInstance *TOTPRepository
+)
+
+func NewTOTPRepository(db *database.Database) ITOTPRepository {
+	totpRepoOnce.Do(func() {
+		totpRepoInstance = &TOTPRepository{}
+		totpRepoInstance.db = db
+	})
+	return totpRepoInstance
+}
+
+func (r *TOTPRepository) GetByUserID(userID int) (*domain.TOTPCredential, error) {
+	row := r.db.QueryRow("SELECT UserID, Secret, Enabled, LastStep FROM UserTOTP WHERE UserID = ?", userID)
+
+	var c domain.TOTPCredential
+	err := row.Scan(&c.UserID, &c.Secret, &c.Enabled, &c.LastStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Save stores the credential, replacing any previous one of the user.
+func (r *TOTPRepository) Save(credential *domain.TOTPCredential) error {
+	_, err := r.db.Exec("REPLACE INTO UserTOTP (UserID, Secret, Enabled, LastStep) VALUES (?, ?, ?, ?)", credential.UserID, credential.Secret, credential.Enabled, credential.LastStep)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TOTPRepository) Enable(userID int) error {
+	_, err := r.db.Exec("UPDATE UserTOTP SET Enabled = TRUE WHERE UserID = ?", userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateLastStep records the step of an accepted code. It reports false when
+// a code from that step or a later one was already accepted.
+func (r *TOTPRepository) UpdateLastStep(userID int, step int64) (bool, error) {
+	result, err := r.db.Exec("UPDATE UserTOTP SET LastStep = ? WHERE UserID = ? AND LastStep < ?", step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *TOTPRepository) Delete(userID int) error {
+	_, err := r.db.Exec("DELETE FROM UserTOTP WHERE UserID = ?", userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
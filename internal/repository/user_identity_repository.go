@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IUserIdentityRepository interface {
+	GetByIssuerAndSubject(issuer, subject string) (*domain.UserIdentity, error)
+	Create(identity *domain.UserIdentity) error
+}
+
+type UserIdentityRepository struct {
+	db *database.Database
+}
+
+var (
+	userIdentityRepoOnce     sync.Once
+	userIdentityRepoInstance *UserIdentityRepository
+)
+
+func NewUserIdentityRepository(db *database.Database) IUserIdentityRepository {
+	userIdentityRepoOnce.Do(func() {
+		userIdentityRepoInstance = &UserIdentityRepository{}
+		userIdentityRepoInstance.db = db
+	})
+	return userIdentityRepoInstance
+}
+
+func (r *UserIdentityRepository) GetByIssuerAndSubject(issuer, subject string) (*domain.UserIdentity, error) {
+	row := r.db.QueryRow("SELECT ID, UserID, Issuer, Subject FROM UserIdentities WHERE Issuer = ? AND Subject = ?", issuer, subject)
+
+	var i domain.UserIdentity
+	err := row.Scan(&i.ID, &i.UserID, &i.Issuer, &i.Subject)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+func (r *UserIdentityRepository) Create(identity *domain.UserIdentity) error {
+	result, err := r.db.Exec("INSERT INTO UserIdentities (UserID, Issuer, Subject) VALUES (?, ?, ?)", identity.UserID, identity.Issuer, identity.Subject)
+	if err != nil {
+		return err
+	}
+
+	identityID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	identity.ID = int(identityID)
+
+	return nil
+}
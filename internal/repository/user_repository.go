@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"sync"
+)
+
+type IUserRepository interface {
+	GetAll() ([]*domain.User, error)
+	GetByID(id int) (*domain.User, error)
+	GetByUsername(username string) (*domain.User, error)
+	GetByEmail(email string) (*domain.User, error)
+	Create(user *domain.User) error
+	SetRole(id int, role string, studentID, professorID *int) error
+	SetEmail(id int, email string) error
+	SetDisabled(id int, disabled bool) error
+	UpdatePassword(id int, passwordHash string) error
+}
+
+type UserRepository struct {
+	db *database.Database
+}
+
+var (
+	userRepoOnce     sync.Once
+	userRepoInstance *UserRepository
+)
+
+func NewUserRepository(db *database.Database) IUserRepository {
+	userRepoOnce.Do(func() {
+		userRepoInstance = &UserRepository{}
+		userRepoInstance.db = db
+	})
+	return userRepoInstance
+}
+
+const userColumns = "ID, Username, Email, PasswordHash, Role, StudentID, ProfessorID, Disabled"
+
+func scanUser(row rowScanner) (*domain.User, error) {
+	var u domain.User
+	var email sql.NullString
+	var studentID, professorID sql.NullInt64
+	err := row.Scan(&u.ID, &u.Username, &email, &u.PasswordHash, &u.Role, &studentID, &professorID, &u.Disabled)
+	if err != nil {
+		return nil, err
+	}
+	u.Email = email.String
+	u.StudentID = nullIntPtr(studentID)
+	u.ProfessorID = nullIntPtr(professorID)
+
+	return &u, nil
+}
+
+func (r *UserRepository) GetAll() ([]*domain.User, error) {
+	rows, err := r.db.Query("SELECT " + userColumns + " FROM Users")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) GetByID(id int) (*domain.User, error) {
+	row := r.db.QueryRow("SELECT "+userColumns+" FROM Users WHERE ID = ?", id)
+
+	user, err := scanUser(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
+	row := r.db.QueryRow("SELECT "+userColumns+" FROM Users WHERE Username = ?", username)
+
+	user, err := scanUser(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
+	row := r.db.QueryRow("SELECT "+userColumns+" FROM Users WHERE Email = ?", email)
+
+	user, err := scanUser(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) Create(user *domain.User) error {
+	stmt, err := r.db.Prepare("INSERT INTO Users (Username, Email, PasswordHash, Role, StudentID, ProfessorID, Disabled) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(user.Username, nullString(user.Email), user.PasswordHash, user.Role, user.StudentID, user.ProfessorID, user.Disabled)
+	if err != nil {
+		return err
+	}
+
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	user.ID = int(userID)
+
+	return nil
+}
+
+func (r *UserRepository) SetRole(id int, role string, studentID, professorID *int) error {
+	_, err := r.db.Exec("UPDATE Users SET Role = ?, StudentID = ?, ProfessorID = ? WHERE ID = ?", role, studentID, professorID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetEmail(id int, email string) error {
+	_, err := r.db.Exec("UPDATE Users SET Email = ? WHERE ID = ?", nullString(email), id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetDisabled(id int, disabled bool) error {
+	_, err := r.db.Exec("UPDATE Users SET Disabled = ? WHERE ID = ?", disabled, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(id int, passwordHash string) error {
+	_, err := r.db.Exec("UPDATE Users SET PasswordHash = ? WHERE ID = ?", passwordHash, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
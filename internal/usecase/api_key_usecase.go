@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"fmt"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/utils"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// apiKeyPrefixLength is how many leading characters of a key are stored in
+// clear so admins can tell keys apart.
+const apiKeyPrefixLength = 8
+
+type IAPIKeyUsecase interface {
+	GetAll() ([]*domain.APIKey, error)
+	Create(actor *domain.Principal, name string, scopes []string) (*domain.APIKey, string, error)
+	Revoke(id string) error
+	Authenticate(key string) (*domain.APIKey, error)
+}
+
+type APIKeyUsecase struct {
+	APIKeyRepo repository.IAPIKeyRepository
+}
+
+var (
+	apiKeyUsecaseInstance *APIKeyUsecase
+	apiKeyUsecaseOnce     sync.Once
+)
+
+func NewAPIKeyUsecase(repo repository.IAPIKeyRepository) IAPIKeyUsecase {
+	apiKeyUsecaseOnce.Do(func() {
+		apiKeyUsecaseInstance = &APIKeyUsecase{
+			APIKeyRepo: repo,
+		}
+	})
+	return apiKeyUsecaseInstance
+}
+
+func (uc *APIKeyUsecase) GetAll() ([]*domain.APIKey, error) {
+	return uc.APIKeyRepo.GetAll()
+}
+
+// Create generates a new key. The plain key is only returned here; it
+// can't be recovered afterwards. Keys can't create other keys.
+func (uc *APIKeyUsecase) Create(actor *domain.Principal, name string, scopes []string) (*domain.APIKey, string, error) {
+	if actor.UserID == 0 {
+		return nil, "", domain.ErrForbidden
+	}
+
+	plain, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &domain.APIKey{
+		Name:      name,
+		Prefix:    plain[:apiKeyPrefixLength],
+		KeyHash:   utils.HashToken(plain),
+		Scopes:    scopes,
+		CreatedBy: actor.UserID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := key.Validate(); err != nil {
+		return nil, "", fmt.Errorf("error validating api key data: %v", err)
+	}
+
+	if err := uc.APIKeyRepo.Create(key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+func (uc *APIKeyUsecase) Revoke(id string) error {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	key, err := uc.APIKeyRepo.GetByID(intID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return domain.ErrNotFound
+	}
+
+	return uc.APIKeyRepo.Revoke(key.ID)
+}
+
+// Authenticate returns the active key matching the plain key and records
+// that it was used.
+func (uc *APIKeyUsecase) Authenticate(plain string) (*domain.APIKey, error) {
+	key, err := uc.APIKeyRepo.GetByHash(utils.HashToken(plain))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.Revoked {
+		return nil, domain.ErrInvalidToken
+	}
+
+	now := time.Now().UTC()
+	if err := uc.APIKeyRepo.UpdateLastUsed(key.ID, now); err != nil {
+		return nil, err
+	}
+	key.LastUsedAt = &now
+
+	return key, nil
+}
@@ -0,0 +1,520 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type IAssessmentUsecase interface {
+	GetPlan(courseID string) (*domain.AssessmentPlan, error)
+	CreateComponent(actor *domain.Principal, courseID string, component *domain.AssessmentComponent) error
+	UpdateComponent(actor *domain.Principal, courseID string, component *domain.AssessmentComponent) error
+	DeleteComponent(actor *domain.Principal, courseID string, componentID string) error
+	SetDropLowest(actor *domain.Principal, courseID string, rule *domain.DropLowestRule) error
+	RecordScores(actor *domain.Principal, courseID string, componentID string, entry *domain.ScoreEntry) ([]*domain.Grade, error)
+	GetScores(actor *domain.Principal, courseID string, studentID string, termID string) ([]*domain.AssessmentScore, error)
+}
+
+type AssessmentUsecase struct {
+	AssessmentRepo         repository.IAssessmentRepository
+	CourseRepo             repository.ICourseRepository
+	GradeRepo              repository.IGradeRepository
+	TeachingAssignmentRepo repository.ITeachingAssignmentRepository
+	TermRepo               repository.ITermRepository
+	GradingScaleRepo       repository.IGradingScaleRepository
+	StudentRepo            repository.IStudentRepository
+	EnrollmentRepo         repository.IEnrollmentRepository
+	AuditRepo              repository.IAuditRepository
+	Transactor             database.ITransactor
+}
+
+var (
+	assessmentUsecaseInstance *AssessmentUsecase
+	assessmentUsecaseOnce     sync.Once
+)
+
+func NewAssessmentUsecase(repo repository.IAssessmentRepository, courseRepo repository.ICourseRepository, gradeRepo repository.IGradeRepository, teachingAssignmentRepo repository.ITeachingAssignmentRepository, termRepo repository.ITermRepository, gradingScaleRepo repository.IGradingScaleRepository, studentRepo repository.IStudentRepository, enrollmentRepo repository.IEnrollmentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IAssessmentUsecase {
+	assessmentUsecaseOnce.Do(func() {
+		assessmentUsecaseInstance = &AssessmentUsecase{
+			AssessmentRepo:         repo,
+			CourseRepo:             courseRepo,
+			GradeRepo:              gradeRepo,
+			TeachingAssignmentRepo: teachingAssignmentRepo,
+			TermRepo:               termRepo,
+			GradingScaleRepo:       gradingScaleRepo,
+			StudentRepo:            studentRepo,
+			EnrollmentRepo:         enrollmentRepo,
+			AuditRepo:              auditRepo,
+			Transactor:             transactor,
+		}
+	})
+	return assessmentUsecaseInstance
+}
+
+// withTx returns a copy of uc whose components, scores, grades and audit
+// entries are read and written in tx.
+func (uc *AssessmentUsecase) withTx(tx *database.Database) *AssessmentUsecase {
+	bound := *uc
+	bound.AssessmentRepo = uc.AssessmentRepo.WithTx(tx)
+	bound.GradeRepo = uc.GradeRepo.WithTx(tx)
+	bound.AuditRepo = uc.AuditRepo.WithTx(tx)
+	return &bound
+}
+
+func (uc *AssessmentUsecase) GetPlan(courseID string) (*domain.AssessmentPlan, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return uc.plan(intCourseID)
+}
+
+func (uc *AssessmentUsecase) plan(courseID int) (*domain.AssessmentPlan, error) {
+	components, err := uc.AssessmentRepo.GetComponentsByCourseID(courseID)
+	if err != nil {
+		return nil, err
+	}
+	dropLowest, err := uc.AssessmentRepo.GetDropLowest(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &domain.AssessmentPlan{CourseID: courseID, Components: components, DropLowest: dropLowest}
+	for _, component := range components {
+		plan.TotalWeight += component.Weight
+	}
+	return plan, nil
+}
+
+// weightsComplete reports whether the weights of a plan sum to 100.
+func weightsComplete(totalWeight float64) bool {
+	return math.Abs(totalWeight-100) < 1e-6
+}
+
+func (uc *AssessmentUsecase) CreateComponent(actor *domain.Principal, courseID string, component *domain.AssessmentComponent) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	component.CourseID = intCourseID
+
+	if err := component.Validate(); err != nil {
+		return err
+	}
+	if _, err := uc.CourseRepo.GetByID(component.CourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	plan, err := uc.plan(component.CourseID)
+	if err != nil {
+		return err
+	}
+	if plan.TotalWeight+component.Weight > 100+1e-6 {
+		return domain.ErrAssessmentWeights
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		if err := uc.AssessmentRepo.CreateComponent(component); err != nil {
+			return err
+		}
+		if err := recordAudit(uc.AuditRepo, actor, domain.AuditActionCreate, domain.AuditEntityComponent, component.ID, nil, component); err != nil {
+			return err
+		}
+		return uc.recompute(actor, component.CourseID)
+	})
+}
+
+func (uc *AssessmentUsecase) UpdateComponent(actor *domain.Principal, courseID string, component *domain.AssessmentComponent) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	component.CourseID = intCourseID
+
+	if err := component.Validate(); err != nil {
+		return err
+	}
+	before, err := uc.component(component.CourseID, component.ID)
+	if err != nil {
+		return err
+	}
+
+	plan, err := uc.plan(component.CourseID)
+	if err != nil {
+		return err
+	}
+	if plan.TotalWeight-before.Weight+component.Weight > 100+1e-6 {
+		return domain.ErrAssessmentWeights
+	}
+	if component.MaxScore < before.MaxScore {
+		if err := uc.checkMaxScore(component); err != nil {
+			return err
+		}
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		if err := uc.AssessmentRepo.UpdateComponent(component); err != nil {
+			return err
+		}
+		if err := recordAudit(uc.AuditRepo, actor, domain.AuditActionUpdate, domain.AuditEntityComponent, component.ID, before, component); err != nil {
+			return err
+		}
+		return uc.recompute(actor, component.CourseID)
+	})
+}
+
+func (uc *AssessmentUsecase) DeleteComponent(actor *domain.Principal, courseID string, componentID string) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	intComponentID, err := strconv.Atoi(componentID)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.component(intCourseID, intComponentID)
+	if err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		if err := uc.AssessmentRepo.DeleteComponent(intComponentID); err != nil {
+			return err
+		}
+		if err := recordAudit(uc.AuditRepo, actor, domain.AuditActionDelete, domain.AuditEntityComponent, intComponentID, before, nil); err != nil {
+			return err
+		}
+		return uc.recompute(actor, intCourseID)
+	})
+}
+
+// SetDropLowest leaves out the lowest scores of each student in the rule's
+// category. A DropLowest of 0 counts them all again. The rule is audited
+// under the course's ID.
+func (uc *AssessmentUsecase) SetDropLowest(actor *domain.Principal, courseID string, rule *domain.DropLowestRule) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+	dropLowest, err := uc.AssessmentRepo.GetDropLowest(intCourseID)
+	if err != nil {
+		return err
+	}
+	before := &domain.DropLowestRule{Category: rule.Category, DropLowest: dropLowest[rule.Category]}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		if err := uc.AssessmentRepo.SetDropLowest(intCourseID, rule.Category, rule.DropLowest); err != nil {
+			return err
+		}
+		if err := recordAudit(uc.AuditRepo, actor, domain.AuditActionUpdate, domain.AuditEntityCategory, intCourseID, before, rule); err != nil {
+			return err
+		}
+		return uc.recompute(actor, intCourseID)
+	})
+}
+
+// RecordScores saves the scores of a component and recomputes the final
+// grade of every student in the entry. The course's weights must sum to
+// 100 first.
+func (uc *AssessmentUsecase) RecordScores(actor *domain.Principal, courseID string, componentID string, entry *domain.ScoreEntry) ([]*domain.Grade, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intComponentID, err := strconv.Atoi(componentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.ProfessorID, err = actingProfessor(actor, entry.ProfessorID); err != nil {
+		return nil, err
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	component, err := uc.component(intCourseID, intComponentID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := uc.plan(intCourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !weightsComplete(plan.TotalWeight) {
+		return nil, domain.ErrAssessmentWeights
+	}
+
+	assigned, err := uc.TeachingAssignmentRepo.Exists(entry.ProfessorID, intCourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, domain.ErrProfessorNotAssigned
+	}
+	if entry.TermID, err = resolveTerm(uc.TermRepo, entry.TermID); err != nil {
+		return nil, err
+	}
+	enrolled, err := enrolledStudents(uc.EnrollmentRepo, intCourseID, entry.TermID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entry.Scores {
+		if entry.Scores[i].Score > component.MaxScore {
+			return nil, domain.ErrScoreOutOfRange
+		}
+		if !enrolled[entry.Scores[i].StudentID] {
+			return nil, fmt.Errorf("%w: student %d", domain.ErrNotEnrolled, entry.Scores[i].StudentID)
+		}
+		if err := checkGradesOpen(uc.StudentRepo, entry.Scores[i].StudentID); err != nil {
+			return nil, err
+		}
+		entry.Scores[i].ComponentID = component.ID
+		entry.Scores[i].TermID = entry.TermID
+	}
+
+	grades := make([]*domain.Grade, 0, len(entry.Scores))
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		if err := uc.AssessmentRepo.SaveScores(entry.Scores); err != nil {
+			return err
+		}
+		for _, score := range entry.Scores {
+			grade, err := uc.saveGrade(actor, plan, score.StudentID, entry.TermID, entry.ProfessorID)
+			if err != nil {
+				return err
+			}
+			if grade != nil {
+				grades = append(grades, grade)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grades, nil
+}
+
+func (uc *AssessmentUsecase) GetScores(actor *domain.Principal, courseID string, studentID string, termID string) ([]*domain.AssessmentScore, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+	if intTermID, err = resolveTerm(uc.TermRepo, intTermID); err != nil {
+		return nil, err
+	}
+
+	return uc.AssessmentRepo.GetScoresByStudent(intCourseID, intStudentID, intTermID)
+}
+
+// component returns the component, or ErrNotFound when it is not part of
+// the course.
+func (uc *AssessmentUsecase) component(courseID, id int) (*domain.AssessmentComponent, error) {
+	component, err := uc.AssessmentRepo.GetComponentByID(id)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if component.CourseID != courseID {
+		return nil, domain.ErrNotFound
+	}
+	return component, nil
+}
+
+// checkMaxScore rejects a maximum score below a score already recorded
+// for the component, which would put the result above 100%.
+func (uc *AssessmentUsecase) checkMaxScore(component *domain.AssessmentComponent) error {
+	scores, err := uc.AssessmentRepo.GetScoresByCourseID(component.CourseID)
+	if err != nil {
+		return err
+	}
+	for _, score := range scores {
+		if score.ComponentID == component.ID && score.Score > component.MaxScore {
+			return domain.ErrScoreOutOfRange
+		}
+	}
+	return nil
+}
+
+// recompute brings the final grades of the course up to date after its
+// plan changed, in the transaction uc is bound to. Grades are left alone while the weights don't sum to 100,
+// and so are those of graduated students.
+func (uc *AssessmentUsecase) recompute(actor *domain.Principal, courseID int) error {
+	plan, err := uc.plan(courseID)
+	if err != nil {
+		return err
+	}
+	if !weightsComplete(plan.TotalWeight) {
+		return nil
+	}
+
+	scores, err := uc.AssessmentRepo.GetScoresByCourseID(courseID)
+	if err != nil {
+		return err
+	}
+	type studentTerm struct{ studentID, termID int }
+	done := make(map[studentTerm]bool)
+	for _, score := range scores {
+		key := studentTerm{score.StudentID, score.TermID}
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		err := checkGradesOpen(uc.StudentRepo, score.StudentID)
+		if errors.Is(err, domain.ErrGradesFrozen) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := uc.saveGrade(actor, plan, score.StudentID, score.TermID, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveGrade computes the student's final grade from their scores and
+// stores it in the course's Grade row for the term. A professorID of 0
+// keeps the professor of the existing row.
+func (uc *AssessmentUsecase) saveGrade(actor *domain.Principal, plan *domain.AssessmentPlan, studentID, termID, professorID int) (*domain.Grade, error) {
+	scores, err := uc.AssessmentRepo.GetScoresByStudent(plan.CourseID, studentID, termID)
+	if err != nil {
+		return nil, err
+	}
+	pct, ok := finalPercentage(plan, scores)
+	if !ok {
+		return nil, nil
+	}
+
+	grades, err := uc.GradeRepo.GetByStudentID(studentID, termID)
+	if err != nil {
+		return nil, err
+	}
+	var before *domain.Grade
+	for _, grade := range grades {
+		if grade.CourseID == plan.CourseID {
+			before = grade
+			break
+		}
+	}
+	if before == nil && professorID == 0 {
+		// Nobody has given this grade yet; it is created with the next score.
+		return nil, nil
+	}
+
+	grade := &domain.Grade{StudentID: studentID, CourseID: plan.CourseID, ProfessorID: professorID, TermID: termID}
+	if before != nil {
+		grade.ID = before.ID
+		if professorID == 0 {
+			grade.ProfessorID = before.ProfessorID
+		}
+	}
+
+	scale, err := uc.GradingScaleRepo.GetByCourseID(plan.CourseID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if scale != nil {
+		grade.Grade = scale.MinGrade + pct*(scale.MaxGrade-scale.MinGrade)
+	} else {
+		grade.Grade = pct * 100
+	}
+	grade.Grade = math.Round(grade.Grade*100) / 100
+	if err := checkGradeScale(uc.GradingScaleRepo, grade); err != nil {
+		return nil, err
+	}
+
+	if before == nil {
+		if err := uc.GradeRepo.Create(grade); err != nil {
+			return nil, err
+		}
+		return grade, recordAudit(uc.AuditRepo, actor, domain.AuditActionCreate, domain.AuditEntityGrade, grade.ID, nil, grade)
+	}
+	if before.Grade == grade.Grade && before.ProfessorID == grade.ProfessorID {
+		return grade, nil
+	}
+	if err := uc.GradeRepo.Update(grade); err != nil {
+		return nil, err
+	}
+	return grade, recordAudit(uc.AuditRepo, actor, domain.AuditActionUpdate, domain.AuditEntityGrade, grade.ID, before, grade)
+}
+
+// finalPercentage returns the student's result in the course from 0 to 1.
+// Every component counts by its weight, and one without a score counts as
+// 0, so the result only grows as scores are recorded. In a category with a
+// drop-lowest rule the lowest results are left out, missing ones first,
+// but never the last one, and the category keeps its weight. It reports
+// false when the student has no scores.
+func finalPercentage(plan *domain.AssessmentPlan, scores []*domain.AssessmentScore) (float64, bool) {
+	scored := make(map[int]float64, len(scores))
+	for _, score := range scores {
+		scored[score.ComponentID] = score.Score
+	}
+
+	type result struct{ pct, weight float64 }
+	categories := make(map[string][]result)
+	var anyScored bool
+	for _, component := range plan.Components {
+		score, ok := scored[component.ID]
+		anyScored = anyScored || ok
+		categories[component.Category] = append(categories[component.Category], result{score / component.MaxScore, component.Weight})
+	}
+	if !anyScored {
+		return 0, false
+	}
+
+	var total, totalWeight float64
+	for category, results := range categories {
+		var categoryWeight float64
+		for _, r := range results {
+			categoryWeight += r.weight
+		}
+
+		drop := plan.DropLowest[category]
+		if drop > len(results)-1 {
+			drop = len(results) - 1
+		}
+		sort.Slice(results, func(i, j int) bool { return results[i].pct < results[j].pct })
+
+		var sum, weight float64
+		for _, r := range results[drop:] {
+			sum += r.pct * r.weight
+			weight += r.weight
+		}
+		total += sum / weight * categoryWeight
+		totalWeight += categoryWeight
+	}
+	return total / totalWeight, true
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"math"
+	"testing"
+)
+
+func TestFinalPercentage(t *testing.T) {
+	// The exam is worth half the grade and each of the two homeworks a
+	// quarter.
+	plan := func(dropLowest map[string]int) *domain.AssessmentPlan {
+		return &domain.AssessmentPlan{
+			CourseID: 1,
+			Components: []*domain.AssessmentComponent{
+				{ID: 1, Category: "exam", Weight: 50, MaxScore: 100},
+				{ID: 2, Category: "homework", Weight: 25, MaxScore: 10},
+				{ID: 3, Category: "homework", Weight: 25, MaxScore: 10},
+			},
+			DropLowest:  dropLowest,
+			TotalWeight: 100,
+		}
+	}
+	score := func(componentID int, score float64) *domain.AssessmentScore {
+		return &domain.AssessmentScore{ComponentID: componentID, StudentID: 1, Score: score}
+	}
+
+	tests := []struct {
+		name       string
+		dropLowest map[string]int
+		scores     []*domain.AssessmentScore
+		want       float64
+		wantOK     bool
+	}{
+		{"every component", nil, []*domain.AssessmentScore{score(1, 80), score(2, 5), score(3, 10)}, 0.775, true},
+		{"full marks", nil, []*domain.AssessmentScore{score(1, 100), score(2, 10), score(3, 10)}, 1, true},
+		{"a missing exam counts as zero", nil, []*domain.AssessmentScore{score(2, 5), score(3, 10)}, 0.375, true},
+		{"one perfect homework", nil, []*domain.AssessmentScore{score(3, 10)}, 0.25, true},
+		{"lowest homework dropped", map[string]int{"homework": 1}, []*domain.AssessmentScore{score(1, 80), score(2, 5), score(3, 10)}, 0.9, true},
+		{"missing homework dropped first", map[string]int{"homework": 1}, []*domain.AssessmentScore{score(1, 80), score(2, 5)}, 0.65, true},
+		{"the last score of a category is never dropped", map[string]int{"homework": 5}, []*domain.AssessmentScore{score(1, 80), score(2, 5), score(3, 10)}, 0.9, true},
+		{"rule of another category", map[string]int{"exam": 1}, []*domain.AssessmentScore{score(1, 80), score(2, 5), score(3, 10)}, 0.775, true},
+		{"no scores", nil, nil, 0, false},
+		{"scores of unknown components", nil, []*domain.AssessmentScore{score(9, 50)}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := finalPercentage(plan(tt.dropLowest), tt.scores)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("finalPercentage() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWeightsComplete(t *testing.T) {
+	tests := []struct {
+		totalWeight float64
+		want        bool
+	}{
+		{100, true},
+		{33.3333333 + 33.3333333 + 33.3333334, true},
+		{99.9, false},
+		{0, false},
+	}
+
+	for _, tt := range tests {
+		if got := weightsComplete(tt.totalWeight); got != tt.want {
+			t.Errorf("weightsComplete(%v) = %v, want %v", tt.totalWeight, got, tt.want)
+		}
+	}
+}
+
+type fakeAssessmentRepo struct {
+	repository.IAssessmentRepository
+	components []*domain.AssessmentComponent
+	scores     []*domain.AssessmentScore
+}
+
+func (r *fakeAssessmentRepo) GetComponentsByCourseID(courseID int) ([]*domain.AssessmentComponent, error) {
+	return r.components, nil
+}
+
+func (r *fakeAssessmentRepo) GetComponentByID(id int) (*domain.AssessmentComponent, error) {
+	for _, component := range r.components {
+		if component.ID == id {
+			copied := *component
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeAssessmentRepo) UpdateComponent(component *domain.AssessmentComponent) error {
+	return nil
+}
+
+func (r *fakeAssessmentRepo) GetDropLowest(courseID int) (map[string]int, error) {
+	return nil, nil
+}
+
+func (r *fakeAssessmentRepo) GetScoresByCourseID(courseID int) ([]*domain.AssessmentScore, error) {
+	return r.scores, nil
+}
+
+func (r *fakeAssessmentRepo) GetScoresByStudent(courseID int, studentID int, termID int) ([]*domain.AssessmentScore, error) {
+	var scores []*domain.AssessmentScore
+	for _, score := range r.scores {
+		if score.StudentID == studentID && score.TermID == termID {
+			scores = append(scores, score)
+		}
+	}
+	return scores, nil
+}
+
+func (r *fakeAssessmentRepo) WithTx(tx *database.Database) repository.IAssessmentRepository {
+	return r
+}
+
+type fakeEnrollmentRepo struct {
+	repository.IEnrollmentRepository
+	enrollments []*domain.Enrollment
+}
+
+func (r *fakeEnrollmentRepo) GetByCourseID(courseID int, termID int) ([]*domain.Enrollment, error) {
+	var enrollments []*domain.Enrollment
+	for _, e := range r.enrollments {
+		if e.CourseID == courseID && e.TermID == termID {
+			enrollments = append(enrollments, e)
+		}
+	}
+	return enrollments, nil
+}
+
+func newTestAssessmentUsecase() *AssessmentUsecase {
+	// Course 1 has an exam and a homework marked out of 10. Student 5 scored
+	// 8 in the homework.
+	return &AssessmentUsecase{
+		AssessmentRepo: &fakeAssessmentRepo{
+			components: []*domain.AssessmentComponent{
+				{ID: 1, CourseID: 1, Name: "Exam", Category: "exam", Weight: 50, MaxScore: 100},
+				{ID: 2, CourseID: 1, Name: "Homework", Category: "homework", Weight: 50, MaxScore: 10},
+			},
+			scores: []*domain.AssessmentScore{{ComponentID: 2, StudentID: 5, TermID: 1, Score: 8}},
+		},
+		GradeRepo:              &fakeGradeRepo{grades: map[int]*domain.Grade{}},
+		TeachingAssignmentRepo: &fakeTeachingAssignmentRepo{courses: map[int][]int{3: {1}}},
+		TermRepo:               &fakeTermRepo{},
+		GradingScaleRepo:       &fakeGradingScaleRepo{},
+		StudentRepo:            &fakeStudentRepo{students: map[int]*domain.Student{5: {ID: 5, Status: domain.StudentStatusActive}}},
+		EnrollmentRepo: &fakeEnrollmentRepo{enrollments: []*domain.Enrollment{
+			{StudentID: 5, CourseID: 1, TermID: 1, Status: domain.EnrollmentStatusEnrolled},
+			{StudentID: 6, CourseID: 1, TermID: 1, Status: domain.EnrollmentStatusWaitlisted},
+			{StudentID: 7, CourseID: 1, TermID: 2, Status: domain.EnrollmentStatusEnrolled},
+		}},
+		AuditRepo:  &fakeAuditRepo{},
+		Transactor: fakeTransactor{},
+	}
+}
+
+func TestAssessmentUpdateComponentMaxScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxScore float64
+		err      error
+	}{
+		{"raised", 20, nil},
+		{"lowered to the best score", 8, nil},
+		{"lowered below a recorded score", 5, domain.ErrScoreOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestAssessmentUsecase()
+			component := &domain.AssessmentComponent{ID: 2, Name: "Homework", Category: "homework", Weight: 50, MaxScore: tt.maxScore}
+
+			if err := uc.UpdateComponent(&domain.Principal{Role: domain.RoleAdmin}, "1", component); !errors.Is(err, tt.err) {
+				t.Errorf("UpdateComponent() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAssessmentRecordScoresEnrollment(t *testing.T) {
+	tests := []struct {
+		name      string
+		studentID int
+	}{
+		{"waitlisted", 6},
+		{"enrolled in another term", 7},
+		{"never enrolled", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestAssessmentUsecase()
+			entry := &domain.ScoreEntry{TermID: 1, Scores: []domain.AssessmentScore{{StudentID: 5, Score: 9}, {StudentID: tt.studentID, Score: 9}}}
+
+			_, err := uc.RecordScores(&domain.Principal{Role: domain.RoleProfessor, ProfessorID: 3}, "1", "2", entry)
+			if !errors.Is(err, domain.ErrNotEnrolled) {
+				t.Errorf("RecordScores() = %v, want %v", err, domain.ErrNotEnrolled)
+			}
+		})
+	}
+}
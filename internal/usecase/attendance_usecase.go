@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"fmt"
+	"golang-technical-test/config"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"math"
+	"strconv"
+	"sync"
+)
+
+type IAttendanceUsecase interface {
+	GetSessions(courseID string, termID string) ([]*domain.ClassSession, error)
+	CreateSession(actor *domain.Principal, courseID string, session *domain.ClassSession) error
+	UpdateSession(actor *domain.Principal, courseID string, session *domain.ClassSession) error
+	DeleteSession(actor *domain.Principal, courseID string, sessionID string) error
+	GetRecords(actor *domain.Principal, courseID string, sessionID string) ([]*domain.AttendanceRecord, error)
+	RecordAttendance(actor *domain.Principal, courseID string, sessionID string, entry *domain.AttendanceEntry) ([]*domain.AttendanceRecord, error)
+	GetCourseReport(actor *domain.Principal, courseID string, termID string) (*domain.AttendanceReport, error)
+	GetStudentReport(actor *domain.Principal, studentID string, termID string) (*domain.AttendanceReport, error)
+}
+
+type AttendanceUsecase struct {
+	AttendanceRepo repository.IAttendanceRepository
+	CourseRepo     repository.ICourseRepository
+	SectionRepo    repository.ISectionRepository
+	EnrollmentRepo repository.IEnrollmentRepository
+	TermRepo       repository.ITermRepository
+	AuditRepo      repository.IAuditRepository
+	Transactor     database.ITransactor
+	Config         *config.AcademicConfig
+}
+
+var (
+	attendanceUsecaseInstance *AttendanceUsecase
+	attendanceUsecaseOnce     sync.Once
+)
+
+func NewAttendanceUsecase(repo repository.IAttendanceRepository, courseRepo repository.ICourseRepository, sectionRepo repository.ISectionRepository, enrollmentRepo repository.IEnrollmentRepository, termRepo repository.ITermRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor, cfg *config.AcademicConfig) IAttendanceUsecase {
+	attendanceUsecaseOnce.Do(func() {
+		attendanceUsecaseInstance = &AttendanceUsecase{
+			AttendanceRepo: repo,
+			CourseRepo:     courseRepo,
+			SectionRepo:    sectionRepo,
+			EnrollmentRepo: enrollmentRepo,
+			TermRepo:       termRepo,
+			AuditRepo:      auditRepo,
+			Transactor:     transactor,
+			Config:         cfg,
+		}
+	})
+	return attendanceUsecaseInstance
+}
+
+func (uc *AttendanceUsecase) GetSessions(courseID string, termID string) ([]*domain.ClassSession, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	return uc.AttendanceRepo.GetSessionsByCourseID(intCourseID, intTermID)
+}
+
+// CreateSession adds a session to the course. The term defaults to the
+// section's term, or else to the active term.
+func (uc *AttendanceUsecase) CreateSession(actor *domain.Principal, courseID string, session *domain.ClassSession) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	session.CourseID = intCourseID
+
+	if err := session.Validate(); err != nil {
+		return err
+	}
+	if _, err := uc.CourseRepo.GetByID(session.CourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	var section *domain.Section
+	if session.SectionID != nil {
+		section, err = uc.SectionRepo.GetByID(*session.SectionID)
+		if err != nil {
+			return notFoundIfNoRows(err)
+		}
+		if session.TermID == 0 {
+			session.TermID = section.TermID
+		}
+	}
+	if session.TermID, err = resolveTerm(uc.TermRepo, session.TermID); err != nil {
+		return err
+	}
+	if section != nil && (section.CourseID != session.CourseID || section.TermID != session.TermID) {
+		return domain.ErrSectionMismatch
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.AttendanceRepo.WithTx(tx).CreateSession(session); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntitySession, session.ID, nil, session)
+	})
+}
+
+// UpdateSession changes the date and topic of a session; its course, term
+// and section stay.
+func (uc *AttendanceUsecase) UpdateSession(actor *domain.Principal, courseID string, session *domain.ClassSession) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+
+	if err := session.Validate(); err != nil {
+		return err
+	}
+	before, err := uc.session(intCourseID, session.ID)
+	if err != nil {
+		return err
+	}
+	session.CourseID, session.TermID, session.SectionID = before.CourseID, before.TermID, before.SectionID
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.AttendanceRepo.WithTx(tx).UpdateSession(session); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntitySession, session.ID, before, session)
+	})
+}
+
+func (uc *AttendanceUsecase) DeleteSession(actor *domain.Principal, courseID string, sessionID string) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	intSessionID, err := strconv.Atoi(sessionID)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.session(intCourseID, intSessionID)
+	if err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.AttendanceRepo.WithTx(tx).DeleteSession(intSessionID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntitySession, intSessionID, before, nil)
+	})
+}
+
+func (uc *AttendanceUsecase) GetRecords(actor *domain.Principal, courseID string, sessionID string) ([]*domain.AttendanceRecord, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intSessionID, err := strconv.Atoi(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, 0); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.session(intCourseID, intSessionID); err != nil {
+		return nil, err
+	}
+	return uc.AttendanceRepo.GetRecordsBySessionID(intSessionID)
+}
+
+// RecordAttendance marks the attendance of several students to a session,
+// replacing what was marked before for them. Every student must hold a
+// seat in the course in the session's term, and in its section if it has
+// one.
+func (uc *AttendanceUsecase) RecordAttendance(actor *domain.Principal, courseID string, sessionID string, entry *domain.AttendanceEntry) ([]*domain.AttendanceRecord, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intSessionID, err := strconv.Atoi(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	session, err := uc.session(intCourseID, intSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := enrolledStudents(uc.EnrollmentRepo, session.CourseID, session.TermID, session.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entry.Records {
+		if !enrolled[entry.Records[i].StudentID] {
+			return nil, fmt.Errorf("%w: student %d", domain.ErrNotEnrolled, entry.Records[i].StudentID)
+		}
+		entry.Records[i].SessionID = session.ID
+	}
+
+	var after []*domain.AttendanceRecord
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		attendanceRepo := uc.AttendanceRepo.WithTx(tx)
+		before, err := attendanceRepo.GetRecordsBySessionID(session.ID)
+		if err != nil {
+			return err
+		}
+		if err := attendanceRepo.SaveRecords(entry.Records); err != nil {
+			return err
+		}
+		after, err = attendanceRepo.GetRecordsBySessionID(session.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(before) == 0 {
+			return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityAttendance, session.ID, nil, after)
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityAttendance, session.ID, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (uc *AttendanceUsecase) GetCourseReport(actor *domain.Principal, courseID string, termID string) (*domain.AttendanceReport, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, 0); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	rates, err := uc.AttendanceRepo.GetRatesByCourseID(intCourseID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.report(rates), nil
+}
+
+func (uc *AttendanceUsecase) GetStudentReport(actor *domain.Principal, studentID string, termID string) (*domain.AttendanceReport, error) {
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.AttendanceRepo.GetRatesByStudentID(intStudentID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.report(rates), nil
+}
+
+// report computes the attendance rates and flags those below the
+// threshold. A student whose every absence is excused has a rate of 100.
+func (uc *AttendanceUsecase) report(rates []*domain.AttendanceRate) *domain.AttendanceReport {
+	report := &domain.AttendanceReport{Threshold: uc.Config.AttendanceThreshold, Rates: rates}
+	if report.Rates == nil {
+		report.Rates = []*domain.AttendanceRate{}
+	}
+
+	for _, rate := range report.Rates {
+		counted := rate.Sessions - rate.Excused
+		if counted == 0 {
+			rate.Rate = 100
+			continue
+		}
+		rate.Rate = math.Round(float64(rate.Present+rate.Late)/float64(counted)*10000) / 100
+		rate.AtRisk = rate.Rate < report.Threshold
+	}
+	return report
+}
+
+// session returns the session, or ErrNotFound when it is not part of the
+// course.
+func (uc *AttendanceUsecase) session(courseID, id int) (*domain.ClassSession, error) {
+	session, err := uc.AttendanceRepo.GetSessionByID(id)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if session.CourseID != courseID {
+		return nil, domain.ErrNotFound
+	}
+	return session, nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"encoding/json"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Page sizes of the audit log listing.
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type IAuditUsecase interface {
+	Find(filter *domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
+
+type AuditUsecase struct {
+	AuditRepo repository.IAuditRepository
+}
+
+var (
+	auditUsecaseInstance *AuditUsecase
+	auditUsecaseOnce     sync.Once
+)
+
+func NewAuditUsecase(repo repository.IAuditRepository) IAuditUsecase {
+	auditUsecaseOnce.Do(func() {
+		auditUsecaseInstance = &AuditUsecase{
+			AuditRepo: repo,
+		}
+	})
+	return auditUsecaseInstance
+}
+
+func (uc *AuditUsecase) Find(filter *domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return uc.AuditRepo.Find(filter)
+}
+
+// recordAudit appends a change made by actor to the audit log. before and
+// after are the entity as it was and as it is now; pass nil for the side
+// that doesn't exist. repo must be bound to the transaction making the
+// change, so that the change and its entry commit or roll back together.
+func recordAudit(repo repository.IAuditRepository, actor *domain.Principal, action, entity string, entityID int, before, after interface{}) error {
+	entry := &domain.AuditEntry{
+		ActorUsername: actor.Username,
+		ActorRole:     actor.Role,
+		Action:        action,
+		Entity:        entity,
+		EntityID:      entityID,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if actor.UserID != 0 {
+		userID := actor.UserID
+		entry.ActorUserID = &userID
+	}
+	if entry.ActorRole == "" {
+		entry.ActorRole = strings.Join(actor.Scopes, ",")
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+
+	return repo.Create(entry)
+}
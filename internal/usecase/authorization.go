@@ -0,0 +1,20 @@
+package usecase
+
+import "golang-technical-test/internal/domain"
+
+// authorizeStudentAccess checks that the actor may read records about the
+// given student. Staff may read any student; a student only the student
+// linked to their account. A studentID of 0 means "any student", which only
+// staff may ask for.
+func authorizeStudentAccess(actor *domain.Principal, studentID int) error {
+	if actor == nil {
+		return domain.ErrForbidden
+	}
+	if actor.IsStaff() {
+		return nil
+	}
+	if actor.IsStudent() && actor.StudentID != 0 && actor.StudentID == studentID {
+		return nil
+	}
+	return domain.ErrForbidden
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"testing"
+)
+
+func TestAuthorizeStudentAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		actor     *domain.Principal
+		studentID int
+		err       error
+	}{
+		{"admin", &domain.Principal{Role: domain.RoleAdmin}, 7, nil},
+		{"registrar", &domain.Principal{Role: domain.RoleRegistrar}, 7, nil},
+		{"professor", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 3}, 7, nil},
+		{"the student", &domain.Principal{Role: domain.RoleStudent, StudentID: 7}, 7, nil},
+		{"another student", &domain.Principal{Role: domain.RoleStudent, StudentID: 8}, 7, domain.ErrForbidden},
+		{"student account without a student", &domain.Principal{Role: domain.RoleStudent}, 0, domain.ErrForbidden},
+		{"key scoped to admin", &domain.Principal{Role: domain.RoleStudent, StudentID: 8, Scopes: []string{domain.RoleAdmin}}, 7, nil},
+		{"no caller", nil, 7, domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := authorizeStudentAccess(tt.actor, tt.studentID); !errors.Is(err, tt.err) {
+				t.Errorf("authorizeStudentAccess() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/repository"
 	"strconv"
@@ -10,13 +11,16 @@ import (
 type ICourseUsecase interface {
 	GetAll() ([]*domain.Course, error)
 	GetByID(id string) (*domain.Course, error)
-	Create(course *domain.Course) error
-	Update(course *domain.Course) error
-	Delete(id string) error
+	Create(actor *domain.Principal, course *domain.Course) error
+	Update(actor *domain.Principal, course *domain.Course) error
+	Delete(actor *domain.Principal, id string) error
 }
 
 type CourseUsecase struct {
-	CourseRepo repository.ICourseRepository
+	CourseRepo       repository.ICourseRepository
+	GradingScaleRepo repository.IGradingScaleRepository
+	AuditRepo        repository.IAuditRepository
+	Transactor       database.ITransactor
 }
 
 var (
@@ -24,10 +28,13 @@ var (
 	courseUsecaseOnce     sync.Once
 )
 
-func NewCourseUsecase(repo repository.ICourseRepository) ICourseUsecase {
+func NewCourseUsecase(repo repository.ICourseRepository, gradingScaleRepo repository.IGradingScaleRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) ICourseUsecase {
 	courseUsecaseOnce.Do(func() {
 		courseUsecaseInstance = &CourseUsecase{}
 		courseUsecaseInstance.CourseRepo = repo
+		courseUsecaseInstance.GradingScaleRepo = gradingScaleRepo
+		courseUsecaseInstance.AuditRepo = auditRepo
+		courseUsecaseInstance.Transactor = transactor
 	})
 	return courseUsecaseInstance
 }
@@ -44,23 +51,68 @@ func (u *CourseUsecase) GetByID(id string) (*domain.Course, error) {
 	return u.CourseRepo.GetByID(intID)
 }
 
-func (u *CourseUsecase) Create(course *domain.Course) error {
+func (u *CourseUsecase) Create(actor *domain.Principal, course *domain.Course) error {
 	err := course.Validate()
 	if err != nil {
 		return err
 	}
 
-	return u.CourseRepo.Create(course)
+	if err := u.checkGradingScale(course); err != nil {
+		return err
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.CourseRepo.WithTx(tx).Create(course); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityCourse, course.ID, nil, course)
+	})
 }
 
-func (u *CourseUsecase) Update(course *domain.Course) error {
-	return u.CourseRepo.Update(course)
+func (u *CourseUsecase) Update(actor *domain.Principal, course *domain.Course) error {
+	before, err := u.CourseRepo.GetByID(course.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	if err := u.checkGradingScale(course); err != nil {
+		return err
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.CourseRepo.WithTx(tx).Update(course); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityCourse, course.ID, before, course)
+	})
 }
 
-func (u *CourseUsecase) Delete(id string) error {
+func (u *CourseUsecase) Delete(actor *domain.Principal, id string) error {
 	intID, err := strconv.Atoi(id)
 	if err != nil {
 		return err
 	}
-	return u.CourseRepo.Delete(intID)
+
+	before, err := u.CourseRepo.GetByID(intID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.CourseRepo.WithTx(tx).Delete(intID); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityCourse, intID, before, nil)
+	})
+}
+
+// checkGradingScale makes sure the course's grading scale exists.
+func (u *CourseUsecase) checkGradingScale(course *domain.Course) error {
+	if course.GradingScaleID == nil {
+		return nil
+	}
+	if _, err := u.GradingScaleRepo.GetByID(*course.GradingScaleID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+	return nil
 }
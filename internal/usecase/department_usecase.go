@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type IDepartmentUsecase interface {
+	GetAll() ([]*domain.Department, error)
+	GetByID(id string) (*domain.Department, error)
+	Create(actor *domain.Principal, department *domain.Department) error
+	Update(actor *domain.Principal, department *domain.Department) error
+	Delete(actor *domain.Principal, id string) error
+}
+
+type DepartmentUsecase struct {
+	DepartmentRepo repository.IDepartmentRepository
+	AuditRepo      repository.IAuditRepository
+	Transactor     database.ITransactor
+}
+
+var (
+	departmentUsecaseInstance *DepartmentUsecase
+	departmentUsecaseOnce     sync.Once
+)
+
+func NewDepartmentUsecase(repo repository.IDepartmentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IDepartmentUsecase {
+	departmentUsecaseOnce.Do(func() {
+		departmentUsecaseInstance = &DepartmentUsecase{
+			DepartmentRepo: repo,
+			AuditRepo:      auditRepo,
+			Transactor:     transactor,
+		}
+	})
+	return departmentUsecaseInstance
+}
+
+func (uc *DepartmentUsecase) GetAll() ([]*domain.Department, error) {
+	return uc.DepartmentRepo.GetAll()
+}
+
+func (uc *DepartmentUsecase) GetByID(id string) (*domain.Department, error) {
+	departmentID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	department, err := uc.DepartmentRepo.GetByID(departmentID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return department, nil
+}
+
+func (uc *DepartmentUsecase) Create(actor *domain.Principal, department *domain.Department) error {
+	if err := department.Validate(); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.DepartmentRepo.WithTx(tx).Create(department); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityDepartment, department.ID, nil, department)
+	})
+}
+
+func (uc *DepartmentUsecase) Update(actor *domain.Principal, department *domain.Department) error {
+	if err := department.Validate(); err != nil {
+		return err
+	}
+
+	before, err := uc.DepartmentRepo.GetByID(department.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.DepartmentRepo.WithTx(tx).Update(department); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityDepartment, department.ID, before, department)
+	})
+}
+
+// Delete removes a department with no professors or programs.
+func (uc *DepartmentUsecase) Delete(actor *domain.Principal, id string) error {
+	departmentID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.DepartmentRepo.GetByID(departmentID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	used, err := uc.DepartmentRepo.IsUsed(departmentID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.DepartmentRepo.WithTx(tx).Delete(departmentID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityDepartment, departmentID, before, nil)
+	})
+}
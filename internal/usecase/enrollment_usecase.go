@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/repository"
 	"strconv"
@@ -8,17 +11,26 @@ import (
 )
 
 type IEnrollmentUsecase interface {
-	GetAll() ([]*domain.Enrollment, error)
-	GetByID(id string) (*domain.Enrollment, error)
-	Create(enrollment *domain.Enrollment) error
-	Update(enrollment *domain.Enrollment) error
-	Delete(id string) error
-	GetByStudentID(studentID string) ([]*domain.Enrollment, error)
-	GetByCourseID(courseID string) ([]*domain.Enrollment, error)
+	GetAll(actor *domain.Principal, termID string) ([]*domain.Enrollment, error)
+	GetByID(actor *domain.Principal, id string) (*domain.Enrollment, error)
+	Create(actor *domain.Principal, enrollment *domain.Enrollment) error
+	Update(actor *domain.Principal, enrollment *domain.Enrollment) error
+	Delete(actor *domain.Principal, id string) error
+	GetByStudentID(actor *domain.Principal, studentID string, termID string) ([]*domain.Enrollment, error)
+	GetByCourseID(actor *domain.Principal, courseID string, termID string) ([]*domain.Enrollment, error)
 }
 
 type EnrollmentUsecase struct {
-	EnrollmentRepo repository.IEnrollmentRepository
+	EnrollmentRepo   repository.IEnrollmentRepository
+	TermRepo         repository.ITermRepository
+	SectionRepo      repository.ISectionRepository
+	PrerequisiteRepo repository.IPrerequisiteRepository
+	GradeRepo        repository.IGradeRepository
+	GradingScaleRepo repository.IGradingScaleRepository
+	MeetingRepo      repository.IMeetingRepository
+	StudentRepo      repository.IStudentRepository
+	AuditRepo        repository.IAuditRepository
+	Transactor       database.ITransactor
 }
 
 var (
@@ -26,74 +38,412 @@ var (
 	enrollmentUsecaseOnce     sync.Once
 )
 
-func NewEnrollmentUsecase(repo repository.IEnrollmentRepository) IEnrollmentUsecase {
+func NewEnrollmentUsecase(repo repository.IEnrollmentRepository, termRepo repository.ITermRepository, sectionRepo repository.ISectionRepository, prerequisiteRepo repository.IPrerequisiteRepository, gradeRepo repository.IGradeRepository, gradingScaleRepo repository.IGradingScaleRepository, meetingRepo repository.IMeetingRepository, studentRepo repository.IStudentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IEnrollmentUsecase {
 	enrollmentUsecaseOnce.Do(func() {
 		enrollmentUsecaseInstance = &EnrollmentUsecase{
-			EnrollmentRepo: repo,
+			EnrollmentRepo:   repo,
+			TermRepo:         termRepo,
+			SectionRepo:      sectionRepo,
+			PrerequisiteRepo: prerequisiteRepo,
+			GradeRepo:        gradeRepo,
+			GradingScaleRepo: gradingScaleRepo,
+			MeetingRepo:      meetingRepo,
+			StudentRepo:      studentRepo,
+			AuditRepo:        auditRepo,
+			Transactor:       transactor,
 		}
 	})
 	return enrollmentUsecaseInstance
 }
 
-func (u *EnrollmentUsecase) GetAll() ([]*domain.Enrollment, error) {
-	return u.EnrollmentRepo.GetAll()
+func (u *EnrollmentUsecase) GetAll(actor *domain.Principal, termID string) ([]*domain.Enrollment, error) {
+	termIDInt, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.IsStudent() {
+		return u.EnrollmentRepo.GetByStudentID(actor.StudentID, termIDInt)
+	}
+	if err := authorizeStudentAccess(actor, 0); err != nil {
+		return nil, err
+	}
+
+	return u.EnrollmentRepo.GetAll(termIDInt)
 }
 
-func (u *EnrollmentUsecase) GetByID(id string) (*domain.Enrollment, error) {
+func (u *EnrollmentUsecase) GetByID(actor *domain.Principal, id string) (*domain.Enrollment, error) {
 	enrollmentID, err := strconv.Atoi(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return u.EnrollmentRepo.GetByID(enrollmentID)
+	enrollment, err := u.EnrollmentRepo.GetByID(enrollmentID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	if err := authorizeStudentAccess(actor, enrollment.StudentID); err != nil {
+		return nil, err
+	}
+
+	return enrollment, nil
 }
 
-func (u *EnrollmentUsecase) Create(enrollment *domain.Enrollment) error {
-	err := u.EnrollmentRepo.Create(enrollment)
+// Create enrolls the student. The student must be active and have passed
+// the course's prerequisites, and the course's meetings must not overlap those of the
+// student's other courses in the term. In a course with sections the
+// enrollment must name one; when the section is full it is waitlisted or
+// refused, depending on the section.
+func (u *EnrollmentUsecase) Create(actor *domain.Principal, enrollment *domain.Enrollment) error {
+	if err := checkStudent(u.StudentRepo, enrollment); err != nil {
+		return err
+	}
+
+	if err := u.checkSection(enrollment); err != nil {
+		return err
+	}
+
+	if err := u.checkPrerequisites(enrollment); err != nil {
+		return err
+	}
+
+	if err := u.checkDuplicate(enrollment); err != nil {
+		return err
+	}
+
+	if err := checkSchedule(u.MeetingRepo, u.EnrollmentRepo, enrollment); err != nil {
+		return err
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		var err error
+		if enrollment.SectionID != nil {
+			err = u.EnrollmentRepo.WithTx(tx).Enroll(enrollment)
+		} else {
+			enrollment.Status = domain.EnrollmentStatusEnrolled
+			err = u.EnrollmentRepo.WithTx(tx).Create(enrollment)
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityEnrollment, enrollment.ID, nil, enrollment)
+	})
+}
+
+func (u *EnrollmentUsecase) Update(actor *domain.Principal, enrollment *domain.Enrollment) error {
+	before, err := u.EnrollmentRepo.GetByID(enrollment.ID)
 	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	if enrollment.TermID == 0 {
+		enrollment.TermID = before.TermID
+	}
+	if enrollment.SectionID == nil {
+		enrollment.SectionID = before.SectionID
+	} else if before.SectionID == nil || *enrollment.SectionID != *before.SectionID {
+		return domain.ErrSectionChange
+	}
+	enrollment.Status = before.Status
+
+	if enrollment.StudentID != before.StudentID {
+		if err := checkStudent(u.StudentRepo, enrollment); err != nil {
+			return err
+		}
+	}
+
+	if err := u.checkSection(enrollment); err != nil {
+		return err
+	}
+
+	if enrollment.StudentID != before.StudentID || enrollment.CourseID != before.CourseID {
+		if err := u.checkPrerequisites(enrollment); err != nil {
+			return err
+		}
+	}
+
+	if err := u.checkDuplicate(enrollment); err != nil {
 		return err
 	}
 
+	if enrollment.StudentID != before.StudentID || enrollment.CourseID != before.CourseID || enrollment.TermID != before.TermID {
+		if err := checkSchedule(u.MeetingRepo, u.EnrollmentRepo, enrollment); err != nil {
+			return err
+		}
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.EnrollmentRepo.WithTx(tx).Update(enrollment); err != nil {
+			return err
+		}
+
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, before, enrollment)
+	})
+}
+
+// checkStudent only lets active students enroll.
+func checkStudent(studentRepo repository.IStudentRepository, enrollment *domain.Enrollment) error {
+	status, err := studentStatus(studentRepo, enrollment.StudentID)
+	if err != nil {
+		return err
+	}
+	if status != domain.StudentStatusActive {
+		return fmt.Errorf("%w: the student is %s", domain.ErrStudentNotActive, status)
+	}
 	return nil
 }
 
-func (u *EnrollmentUsecase) Update(enrollment *domain.Enrollment) error {
-	err := u.EnrollmentRepo.Update(enrollment)
+// checkSection resolves the term of the enrollment and checks its section,
+// which must belong to the same course and term. The term defaults to the
+// section's term, or else to the active term. A course with sections in the
+// term can only be enrolled in through one of them.
+func (u *EnrollmentUsecase) checkSection(enrollment *domain.Enrollment) error {
+	var section *domain.Section
+	if enrollment.SectionID != nil {
+		var err error
+		section, err = u.SectionRepo.GetByID(*enrollment.SectionID)
+		if err != nil {
+			return notFoundIfNoRows(err)
+		}
+		if enrollment.TermID == 0 {
+			enrollment.TermID = section.TermID
+		}
+	}
+
+	var err error
+	enrollment.TermID, err = resolveTerm(u.TermRepo, enrollment.TermID)
 	if err != nil {
 		return err
 	}
 
+	if section != nil {
+		if section.CourseID != enrollment.CourseID || section.TermID != enrollment.TermID {
+			return domain.ErrSectionMismatch
+		}
+		return nil
+	}
+
+	sections, err := u.SectionRepo.GetByCourseID(enrollment.CourseID, enrollment.TermID)
+	if err != nil {
+		return err
+	}
+	if len(sections) > 0 {
+		return domain.ErrSectionRequired
+	}
 	return nil
 }
 
-func (u *EnrollmentUsecase) Delete(id string) error {
-	enrollmentID, err := strconv.Atoi(id)
+// checkPrerequisites returns a *domain.UnmetPrerequisitesError when the
+// student has not passed every prerequisite of the course.
+func (u *EnrollmentUsecase) checkPrerequisites(enrollment *domain.Enrollment) error {
+	prerequisites, err := u.PrerequisiteRepo.GetByCourseID(enrollment.CourseID)
+	if err != nil || len(prerequisites) == 0 {
+		return err
+	}
+
+	grades, err := u.GradeRepo.GetByStudentID(enrollment.StudentID, 0)
 	if err != nil {
 		return err
 	}
+	if err := describeGrades(u.GradingScaleRepo, grades...); err != nil {
+		return err
+	}
 
-	err = u.EnrollmentRepo.Delete(enrollmentID)
+	if unmet := unmetPrerequisites(prerequisites, grades); len(unmet) > 0 {
+		return &domain.UnmetPrerequisitesError{Prerequisites: unmet}
+	}
+	return nil
+}
+
+// checkDuplicate rejects a second enrollment of the student in the same
+// course and term. Retaking a course in a later term is allowed.
+func (u *EnrollmentUsecase) checkDuplicate(enrollment *domain.Enrollment) error {
+	existing, err := u.EnrollmentRepo.GetByStudentID(enrollment.StudentID, enrollment.TermID)
 	if err != nil {
 		return err
 	}
 
+	for _, e := range existing {
+		if e.CourseID == enrollment.CourseID && e.ID != enrollment.ID {
+			return domain.ErrConflict
+		}
+	}
+
 	return nil
 }
 
-func (u *EnrollmentUsecase) GetByStudentID(studentID string) ([]*domain.Enrollment, error) {
+// checkSchedule returns a *domain.ScheduleConflictError when a meeting of
+// the course overlaps one of the student's other enrollments in the term,
+// waitlisted ones included.
+func checkSchedule(meetingRepo repository.IMeetingRepository, enrollmentRepo repository.IEnrollmentRepository, enrollment *domain.Enrollment) error {
+	meetings, err := enrollmentMeetings(meetingRepo, enrollment)
+	if err != nil || len(meetings) == 0 {
+		return err
+	}
+
+	existing, err := enrollmentRepo.GetByStudentID(enrollment.StudentID, enrollment.TermID)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []*domain.Meeting
+	for _, e := range existing {
+		if e.ID == enrollment.ID {
+			continue
+		}
+		taken, err := enrollmentMeetings(meetingRepo, e)
+		if err != nil {
+			return err
+		}
+		for _, t := range taken {
+			for _, m := range meetings {
+				if m.Overlaps(t) {
+					conflicts = append(conflicts, t)
+					break
+				}
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &domain.ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// Delete drops the enrollment. A seat it frees in a section goes to the
+// first waitlisted student who can still take it; see promotable.
+func (u *EnrollmentUsecase) Delete(actor *domain.Principal, id string) error {
+	enrollmentID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := u.EnrollmentRepo.GetByID(enrollmentID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		auditRepo := u.AuditRepo.WithTx(tx)
+		enrollmentRepo := u.EnrollmentRepo.WithTx(tx)
+		check := promotable(u.StudentRepo.WithTx(tx), u.MeetingRepo.WithTx(tx), enrollmentRepo)
+		promoted, err := enrollmentRepo.Drop(enrollmentID, check)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(auditRepo, actor, domain.AuditActionDelete, domain.AuditEntityEnrollment, enrollmentID, before, nil); err != nil {
+			return err
+		}
+		return recordPromotions(auditRepo, actor, promoted)
+	})
+}
+
+// enrolledStudents returns the students holding a seat in the course in the
+// term, leaving out the waitlist. With a sectionID only that section counts.
+func enrolledStudents(enrollmentRepo repository.IEnrollmentRepository, courseID, termID int, sectionID *int) (map[int]bool, error) {
+	enrollments, err := enrollmentRepo.GetByCourseID(courseID, termID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled := make(map[int]bool, len(enrollments))
+	for _, e := range enrollments {
+		if e.Status == domain.EnrollmentStatusWaitlisted {
+			continue
+		}
+		if sectionID != nil && (e.SectionID == nil || *e.SectionID != *sectionID) {
+			continue
+		}
+		enrolled[e.StudentID] = true
+	}
+	return enrolled, nil
+}
+
+// promotable returns the check a waitlisted enrollment must pass to be
+// given a seat: as when enrolling, the student must be active and the
+// course's meetings must not overlap the student's other enrollments.
+// Enrollments that fail it stay waitlisted.
+func promotable(studentRepo repository.IStudentRepository, meetingRepo repository.IMeetingRepository, enrollmentRepo repository.IEnrollmentRepository) repository.PromotionCheck {
+	return func(enrollment *domain.Enrollment) (bool, error) {
+		err := checkStudent(studentRepo, enrollment)
+		if err == nil {
+			err = checkSchedule(meetingRepo, enrollmentRepo, enrollment)
+		}
+
+		var conflict *domain.ScheduleConflictError
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, domain.ErrStudentNotActive), errors.As(err, &conflict):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+}
+
+// recordPromotions audits waitlisted enrollments that were given a seat.
+func recordPromotions(auditRepo repository.IAuditRepository, actor *domain.Principal, promoted []*domain.Enrollment) error {
+	for _, enrollment := range promoted {
+		before := *enrollment
+		before.Status = domain.EnrollmentStatusWaitlisted
+		if err := recordAudit(auditRepo, actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, &before, enrollment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *EnrollmentUsecase) GetByStudentID(actor *domain.Principal, studentID string, termID string) ([]*domain.Enrollment, error) {
 	studentIDInt, err := strconv.Atoi(studentID)
 	if err != nil {
 		return nil, err
 	}
 
-	return u.EnrollmentRepo.GetByStudentID(studentIDInt)
+	termIDInt, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeStudentAccess(actor, studentIDInt); err != nil {
+		return nil, err
+	}
+
+	return u.EnrollmentRepo.GetByStudentID(studentIDInt, termIDInt)
 }
 
-func (u *EnrollmentUsecase) GetByCourseID(courseID string) ([]*domain.Enrollment, error) {
+func (u *EnrollmentUsecase) GetByCourseID(actor *domain.Principal, courseID string, termID string) ([]*domain.Enrollment, error) {
 	courseIDInt, err := strconv.Atoi(courseID)
 	if err != nil {
 		return nil, err
 	}
 
-	return u.EnrollmentRepo.GetByCourseID(courseIDInt)
+	termIDInt, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments, err := u.EnrollmentRepo.GetByCourseID(courseIDInt, termIDInt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsStudent() {
+		if err := authorizeStudentAccess(actor, 0); err != nil {
+			return nil, err
+		}
+		return enrollments, nil
+	}
+
+	var own []*domain.Enrollment
+	for _, enrollment := range enrollments {
+		if enrollment.StudentID == actor.StudentID {
+			own = append(own, enrollment)
+		}
+	}
+
+	return own, nil
 }
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"golang-technical-test/config"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type IGPAUsecase interface {
+	GetByStudentID(actor *domain.Principal, studentID string) (*domain.GPA, error)
+}
+
+type GPAUsecase struct {
+	GradeRepo        repository.IGradeRepository
+	CourseRepo       repository.ICourseRepository
+	TermRepo         repository.ITermRepository
+	StudentRepo      repository.IStudentRepository
+	GradingScaleRepo repository.IGradingScaleRepository
+	Config           *config.AcademicConfig
+}
+
+var (
+	gpaUsecaseInstance *GPAUsecase
+	gpaUsecaseOnce     sync.Once
+)
+
+func NewGPAUsecase(gradeRepo repository.IGradeRepository, courseRepo repository.ICourseRepository, termRepo repository.ITermRepository, studentRepo repository.IStudentRepository, gradingScaleRepo repository.IGradingScaleRepository, cfg *config.AcademicConfig) IGPAUsecase {
+	gpaUsecaseOnce.Do(func() {
+		gpaUsecaseInstance = &GPAUsecase{
+			GradeRepo:        gradeRepo,
+			CourseRepo:       courseRepo,
+			TermRepo:         termRepo,
+			StudentRepo:      studentRepo,
+			GradingScaleRepo: gradingScaleRepo,
+			Config:           cfg,
+		}
+	})
+	return gpaUsecaseInstance
+}
+
+// GetByStudentID computes the student's GPA for each term and overall, on
+// grade points from 0 to domain.MaxGradePoints. A course graded more than
+// once counts once, with the grade chosen by the configured retake rule.
+func (uc *GPAUsecase) GetByStudentID(actor *domain.Principal, studentID string) (*domain.GPA, error) {
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+
+	student, err := uc.StudentRepo.GetByID(intStudentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	grades, err := uc.GradeRepo.GetByStudentID(intStudentID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := uc.TermRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	termOrder := make(map[int]int, len(terms))
+	for i, term := range terms {
+		termOrder[term.ID] = i
+	}
+
+	// Oldest first, so the last attempt at a course is its latest.
+	sort.SliceStable(grades, func(i, j int) bool {
+		if termOrder[grades[i].TermID] != termOrder[grades[j].TermID] {
+			return termOrder[grades[i].TermID] < termOrder[grades[j].TermID]
+		}
+		return grades[i].ID < grades[j].ID
+	})
+
+	credits, err := uc.courseCredits(grades)
+	if err != nil {
+		return nil, err
+	}
+	points, err := uc.gradePoints(grades, credits)
+	if err != nil {
+		return nil, err
+	}
+
+	gpa := &domain.GPA{StudentID: intStudentID, RetakeRule: uc.Config.RetakeRule, Terms: []domain.TermGPA{}}
+	gpa.Cumulative, gpa.Credits, err = weightedGPA(grades, points, credits, uc.Config.RetakeRule)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, term := range terms {
+		var termGrades []*domain.Grade
+		for _, grade := range grades {
+			if grade.TermID == term.ID {
+				termGrades = append(termGrades, grade)
+			}
+		}
+		if len(termGrades) == 0 {
+			continue
+		}
+
+		termGPA := domain.TermGPA{TermID: term.ID, TermName: term.Name}
+		termGPA.GPA, termGPA.Credits, err = weightedGPA(termGrades, points, credits, uc.Config.RetakeRule)
+		if err != nil {
+			return nil, err
+		}
+		gpa.Terms = append(gpa.Terms, termGPA)
+	}
+
+	return gpa, nil
+}
+
+// courseCredits returns the credits of every course in grades. Courses
+// deleted since they were graded get no credits, so their grades don't
+// count.
+func (uc *GPAUsecase) courseCredits(grades []*domain.Grade) (map[int]int, error) {
+	credits := make(map[int]int)
+	for _, grade := range grades {
+		if _, ok := credits[grade.CourseID]; ok {
+			continue
+		}
+		course, err := uc.CourseRepo.GetByID(grade.CourseID)
+		if errors.Is(err, sql.ErrNoRows) {
+			credits[grade.CourseID] = 0
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		credits[course.ID] = course.Credits
+	}
+	return credits, nil
+}
+
+// gradePoints converts the grades of the courses with credits to grade
+// points through their courses' grading scales, keyed by grade ID. Grades
+// of courses without a scale are read from 0 to domain.DefaultScaleMax.
+func (uc *GPAUsecase) gradePoints(grades []*domain.Grade, credits map[int]int) (map[int]float64, error) {
+	scales := make(map[int]*domain.GradingScale)
+	points := make(map[int]float64, len(grades))
+	for _, grade := range grades {
+		if credits[grade.CourseID] == 0 {
+			continue
+		}
+		scale, ok := scales[grade.CourseID]
+		if !ok {
+			var err error
+			scale, err = uc.GradingScaleRepo.GetByCourseID(grade.CourseID)
+			if err != nil {
+				return nil, err
+			}
+			scales[grade.CourseID] = scale
+		}
+
+		if scale == nil {
+			points[grade.ID] = domain.DefaultGradePoints(grade.Grade)
+		} else {
+			points[grade.ID] = scale.GradePoints(grade.Grade)
+		}
+	}
+	return points, nil
+}
+
+// weightedGPA returns the credit-weighted average of the grade points of
+// grades, oldest first, and the credits it covers. Each course counts once,
+// with the grade picked by rule.
+func weightedGPA(grades []*domain.Grade, points map[int]float64, credits map[int]int, rule string) (float64, int, error) {
+	var courses []int
+	attempts := make(map[int][]float64)
+	for _, grade := range grades {
+		if _, ok := attempts[grade.CourseID]; !ok {
+			courses = append(courses, grade.CourseID)
+		}
+		attempts[grade.CourseID] = append(attempts[grade.CourseID], points[grade.ID])
+	}
+
+	var total float64
+	var totalCredits int
+	for _, courseID := range courses {
+		if credits[courseID] == 0 {
+			continue
+		}
+		grade, err := retakeGrade(attempts[courseID], rule)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += grade * float64(credits[courseID])
+		totalCredits += credits[courseID]
+	}
+
+	if totalCredits == 0 {
+		return 0, 0, nil
+	}
+	return math.Round(total/float64(totalCredits)*100) / 100, totalCredits, nil
+}
+
+// retakeGrade picks the grade that counts among the attempts at a course,
+// oldest first.
+func retakeGrade(attempts []float64, rule string) (float64, error) {
+	switch rule {
+	case domain.RetakeRuleLatest:
+		return attempts[len(attempts)-1], nil
+	case domain.RetakeRuleBest:
+		best := attempts[0]
+		for _, grade := range attempts[1:] {
+			best = math.Max(best, grade)
+		}
+		return best, nil
+	case domain.RetakeRuleAverage:
+		var sum float64
+		for _, grade := range attempts {
+			sum += grade
+		}
+		return sum / float64(len(attempts)), nil
+	default:
+		return 0, fmt.Errorf("unknown retake rule %q", rule)
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"math"
+	"testing"
+)
+
+func TestWeightedGPA(t *testing.T) {
+	// Course 1 was taken twice; course 2 once.
+	grades := []*domain.Grade{
+		{ID: 1, CourseID: 1, TermID: 1},
+		{ID: 2, CourseID: 2, TermID: 1},
+		{ID: 3, CourseID: 1, TermID: 2},
+	}
+	points := map[int]float64{1: 2, 2: 4, 3: 3.5}
+
+	tests := []struct {
+		name        string
+		credits     map[int]int
+		rule        string
+		want        float64
+		wantCredits int
+	}{
+		{"latest attempt", map[int]int{1: 3, 2: 4}, domain.RetakeRuleLatest, 3.79, 7},
+		{"best attempt", map[int]int{1: 3, 2: 4}, domain.RetakeRuleBest, 3.79, 7},
+		{"average of the attempts", map[int]int{1: 3, 2: 4}, domain.RetakeRuleAverage, 3.46, 7},
+		{"courses without credits are left out", map[int]int{1: 3}, domain.RetakeRuleLatest, 3.5, 3},
+		{"no credits", map[int]int{}, domain.RetakeRuleLatest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, credits, err := weightedGPA(grades, points, tt.credits, tt.rule)
+			if err != nil {
+				t.Fatalf("weightedGPA() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 || credits != tt.wantCredits {
+				t.Errorf("weightedGPA() = %v, %d, want %v, %d", got, credits, tt.want, tt.wantCredits)
+			}
+		})
+	}
+
+	if _, _, err := weightedGPA(grades, points, map[int]int{1: 3}, "worst"); err == nil {
+		t.Error("weightedGPA() with an unknown rule: error = nil")
+	}
+}
+
+func TestRetakeGrade(t *testing.T) {
+	attempts := []float64{1, 3.5, 2}
+
+	tests := []struct {
+		rule string
+		want float64
+	}{
+		{domain.RetakeRuleLatest, 2},
+		{domain.RetakeRuleBest, 3.5},
+		{domain.RetakeRuleAverage, 6.5 / 3},
+	}
+
+	for _, tt := range tests {
+		got, err := retakeGrade(attempts, tt.rule)
+		if err != nil || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("retakeGrade(%s) = %v, %v, want %v", tt.rule, got, err, tt.want)
+		}
+	}
+}
+
+type fakeGradingScaleRepo struct {
+	repository.IGradingScaleRepository
+	scales map[int]*domain.GradingScale
+}
+
+func (r *fakeGradingScaleRepo) GetByCourseID(courseID int) (*domain.GradingScale, error) {
+	return r.scales[courseID], nil
+}
+
+func TestGPAUsecaseGradePoints(t *testing.T) {
+	a, b := 4.0, 3.0
+	letters := &domain.GradingScale{MinGrade: 0, MaxGrade: 100, Ranges: []domain.GradeRange{
+		{Letter: "F", MinGrade: 0},
+		{Letter: "B", MinGrade: 80, Passing: true, Points: &b},
+		{Letter: "A", MinGrade: 90, Passing: true, Points: &a},
+	}}
+	fivePoint := &domain.GradingScale{MinGrade: 0, MaxGrade: 5, Ranges: []domain.GradeRange{{Letter: "fail", MinGrade: 0}}}
+
+	uc := &GPAUsecase{
+		CourseRepo: &fakeCourseRepo{courses: map[int]*domain.Course{
+			1: {ID: 1, Credits: 3},
+			2: {ID: 2, Credits: 4},
+			3: {ID: 3, Credits: 2},
+			4: {ID: 4, Credits: 0},
+		}},
+		GradingScaleRepo: &fakeGradingScaleRepo{scales: map[int]*domain.GradingScale{1: letters, 2: fivePoint}},
+	}
+	grades := []*domain.Grade{
+		{ID: 10, CourseID: 1, Grade: 85},
+		{ID: 11, CourseID: 2, Grade: 5},
+		{ID: 12, CourseID: 3, Grade: 75},
+		{ID: 13, CourseID: 4, Grade: 100},
+		// Course 5 was deleted after the grade was given.
+		{ID: 14, CourseID: 5, Grade: 100},
+	}
+
+	credits, err := uc.courseCredits(grades)
+	if err != nil {
+		t.Fatalf("courseCredits() error = %v", err)
+	}
+	if credits[5] != 0 {
+		t.Errorf("credits of a deleted course = %d, want 0", credits[5])
+	}
+
+	points, err := uc.gradePoints(grades, credits)
+	if err != nil {
+		t.Fatalf("gradePoints() error = %v", err)
+	}
+	want := map[int]float64{10: 3, 11: 4, 12: 3}
+	if len(points) != len(want) {
+		t.Errorf("gradePoints() = %v, want %v", points, want)
+	}
+	for id, p := range want {
+		if math.Abs(points[id]-p) > 1e-9 {
+			t.Errorf("points of grade %d = %v, want %v", id, points[id], p)
+		}
+	}
+
+	gpa, total, err := weightedGPA(grades, points, credits, domain.RetakeRuleLatest)
+	if err != nil {
+		t.Fatalf("weightedGPA() error = %v", err)
+	}
+	// (3*3 + 4*4 + 3*2) / 9
+	if gpa != 3.44 || total != 9 {
+		t.Errorf("weightedGPA() = %v, %d, want 3.44, 9", gpa, total)
+	}
+}
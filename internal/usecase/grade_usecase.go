@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/repository"
 	"strconv"
@@ -8,18 +9,24 @@ import (
 )
 
 type IGradeUsecase interface {
-	GetAll() ([]*domain.Grade, error)
-	GetByID(id string) (*domain.Grade, error)
-	Create(grade *domain.Grade) error
-	Update(grade *domain.Grade) error
-	Delete(id string) error
-	GetByStudentID(studentID string) ([]*domain.Grade, error)
-	GetByCourseID(courseID string) ([]*domain.Grade, error)
-	GetByProfessorID(professorID string) ([]*domain.Grade, error)
+	GetAll(actor *domain.Principal, termID string) ([]*domain.Grade, error)
+	GetByID(actor *domain.Principal, id string) (*domain.Grade, error)
+	Create(actor *domain.Principal, grade *domain.Grade) error
+	Update(actor *domain.Principal, grade *domain.Grade) error
+	Delete(actor *domain.Principal, id string) error
+	GetByStudentID(actor *domain.Principal, studentID string, termID string) ([]*domain.Grade, error)
+	GetByCourseID(actor *domain.Principal, courseID string, termID string) ([]*domain.Grade, error)
+	GetByProfessorID(actor *domain.Principal, professorID string, termID string) ([]*domain.Grade, error)
 }
 
 type GradeUsecase struct {
-	GradeRepo repository.IGradeRepository
+	GradeRepo              repository.IGradeRepository
+	TeachingAssignmentRepo repository.ITeachingAssignmentRepository
+	TermRepo               repository.ITermRepository
+	GradingScaleRepo       repository.IGradingScaleRepository
+	StudentRepo            repository.IStudentRepository
+	AuditRepo              repository.IAuditRepository
+	Transactor             database.ITransactor
 }
 
 var (
@@ -27,71 +34,236 @@ var (
 	gradeUsecaseOnce     sync.Once
 )
 
-func NewGradeUsecase(repo repository.IGradeRepository) IGradeUsecase {
+func NewGradeUsecase(repo repository.IGradeRepository, teachingAssignmentRepo repository.ITeachingAssignmentRepository, termRepo repository.ITermRepository, gradingScaleRepo repository.IGradingScaleRepository, studentRepo repository.IStudentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IGradeUsecase {
 	gradeUsecaseOnce.Do(func() {
 		gradeUsecaseInstance = &GradeUsecase{
-			GradeRepo: repo,
+			GradeRepo:              repo,
+			TeachingAssignmentRepo: teachingAssignmentRepo,
+			TermRepo:               termRepo,
+			GradingScaleRepo:       gradingScaleRepo,
+			StudentRepo:            studentRepo,
+			AuditRepo:              auditRepo,
+			Transactor:             transactor,
 		}
 	})
 	return gradeUsecaseInstance
 }
 
-func (uc *GradeUsecase) GetAll() ([]*domain.Grade, error) {
-	return uc.GradeRepo.GetAll()
+func (uc *GradeUsecase) GetAll(actor *domain.Principal, termID string) ([]*domain.Grade, error) {
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	var grades []*domain.Grade
+	if actor.IsStudent() {
+		grades, err = uc.GradeRepo.GetByStudentID(actor.StudentID, intTermID)
+	} else {
+		if err := authorizeStudentAccess(actor, 0); err != nil {
+			return nil, err
+		}
+		grades, err = uc.GradeRepo.GetAll(intTermID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return grades, describeGrades(uc.GradingScaleRepo, grades...)
 }
 
-func (uc *GradeUsecase) GetByID(id string) (*domain.Grade, error) {
+func (uc *GradeUsecase) GetByID(actor *domain.Principal, id string) (*domain.Grade, error) {
 	gradeID, err := strconv.Atoi(id)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GradeRepo.GetByID(gradeID)
+	grade, err := uc.GradeRepo.GetByID(gradeID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if err := authorizeStudentAccess(actor, grade.StudentID); err != nil {
+		return nil, err
+	}
+	return grade, describeGrades(uc.GradingScaleRepo, grade)
 }
 
-func (uc *GradeUsecase) Create(grade *domain.Grade) error {
-	err := grade.Validate()
+func (uc *GradeUsecase) Create(actor *domain.Principal, grade *domain.Grade) error {
+	var err error
+	if grade.ProfessorID, err = actingProfessor(actor, grade.ProfessorID); err != nil {
+		return err
+	}
+	err = grade.Validate()
 	if err != nil {
 		return err
 	}
-	return uc.GradeRepo.Create(grade)
+	if err := uc.checkAssignment(grade.ProfessorID, grade.CourseID); err != nil {
+		return err
+	}
+	if err := checkGradeScale(uc.GradingScaleRepo, grade); err != nil {
+		return err
+	}
+	if err := checkGradesOpen(uc.StudentRepo, grade.StudentID); err != nil {
+		return err
+	}
+	if grade.TermID, err = resolveTerm(uc.TermRepo, grade.TermID); err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradeRepo.WithTx(tx).Create(grade); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityGrade, grade.ID, nil, grade)
+	})
+}
+
+func (uc *GradeUsecase) Update(actor *domain.Principal, grade *domain.Grade) error {
+	var err error
+	if grade.ProfessorID, err = actingProfessor(actor, grade.ProfessorID); err != nil {
+		return err
+	}
+	err = grade.Validate()
+	if err != nil {
+		return err
+	}
+	before, err := uc.GradeRepo.GetByID(grade.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+	// The professor must teach the course the grade is in now, not only the
+	// one it is moved to, or any grade could be taken over.
+	if before.CourseID != grade.CourseID {
+		if err := uc.checkAssignment(grade.ProfessorID, before.CourseID); err != nil {
+			return err
+		}
+	}
+	if err := uc.checkAssignment(grade.ProfessorID, grade.CourseID); err != nil {
+		return err
+	}
+	if err := checkGradeScale(uc.GradingScaleRepo, grade); err != nil {
+		return err
+	}
+	if err := checkGradesOpen(uc.StudentRepo, before.StudentID); err != nil {
+		return err
+	}
+	if grade.StudentID != before.StudentID {
+		if err := checkGradesOpen(uc.StudentRepo, grade.StudentID); err != nil {
+			return err
+		}
+	}
+	if grade.TermID == 0 {
+		grade.TermID = before.TermID
+	}
+	if grade.TermID, err = resolveTerm(uc.TermRepo, grade.TermID); err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradeRepo.WithTx(tx).Update(grade); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityGrade, grade.ID, before, grade)
+	})
 }
 
-func (uc *GradeUsecase) Update(grade *domain.Grade) error {
-	err := grade.Validate()
+// checkAssignment rejects grades given by a professor who does not teach the course.
+func (uc *GradeUsecase) checkAssignment(professorID, courseID int) error {
+	assigned, err := uc.TeachingAssignmentRepo.Exists(professorID, courseID)
 	if err != nil {
 		return err
 	}
-	return uc.GradeRepo.Update(grade)
+	if !assigned {
+		return domain.ErrProfessorNotAssigned
+	}
+	return nil
 }
 
-func (uc *GradeUsecase) Delete(id string) error {
+func (uc *GradeUsecase) Delete(actor *domain.Principal, id string) error {
 	gradeID, err := strconv.Atoi(id)
 	if err != nil {
 		return err
 	}
-	return uc.GradeRepo.Delete(gradeID)
+	before, err := uc.GradeRepo.GetByID(gradeID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+	if err := checkGradesOpen(uc.StudentRepo, before.StudentID); err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradeRepo.WithTx(tx).Delete(gradeID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityGrade, gradeID, before, nil)
+	})
 }
 
-func (uc *GradeUsecase) GetByStudentID(studentID string) ([]*domain.Grade, error) {
+func (uc *GradeUsecase) GetByStudentID(actor *domain.Principal, studentID string, termID string) ([]*domain.Grade, error) {
 	intStudentID, err := strconv.Atoi(studentID)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GradeRepo.GetByStudentID(intStudentID)
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+	grades, err := uc.GradeRepo.GetByStudentID(intStudentID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+	return grades, describeGrades(uc.GradingScaleRepo, grades...)
 }
 
-func (uc *GradeUsecase) GetByCourseID(courseID string) ([]*domain.Grade, error) {
+func (uc *GradeUsecase) GetByCourseID(actor *domain.Principal, courseID string, termID string) ([]*domain.Grade, error) {
 	intCourseID, err := strconv.Atoi(courseID)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GradeRepo.GetByCourseID(intCourseID)
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := uc.GradeRepo.GetByCourseID(intCourseID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+	if grades, err = filterGrades(actor, grades); err != nil {
+		return nil, err
+	}
+	return grades, describeGrades(uc.GradingScaleRepo, grades...)
 }
 
-func (uc *GradeUsecase) GetByProfessorID(professorID string) ([]*domain.Grade, error) {
+func (uc *GradeUsecase) GetByProfessorID(actor *domain.Principal, professorID string, termID string) ([]*domain.Grade, error) {
 	intProfessorID, err := strconv.Atoi(professorID)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GradeRepo.GetByProfessorID(intProfessorID)
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := uc.GradeRepo.GetByProfessorID(intProfessorID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+	if grades, err = filterGrades(actor, grades); err != nil {
+		return nil, err
+	}
+	return grades, describeGrades(uc.GradingScaleRepo, grades...)
+}
+
+// filterGrades drops the grades the actor is not allowed to see.
+func filterGrades(actor *domain.Principal, grades []*domain.Grade) ([]*domain.Grade, error) {
+	if !actor.IsStudent() {
+		if err := authorizeStudentAccess(actor, 0); err != nil {
+			return nil, err
+		}
+		return grades, nil
+	}
+
+	var own []*domain.Grade
+	for _, grade := range grades {
+		if grade.StudentID == actor.StudentID {
+			own = append(own, grade)
+		}
+	}
+	return own, nil
 }
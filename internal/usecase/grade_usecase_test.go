@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"testing"
+)
+
+type fakeGradeRepo struct {
+	repository.IGradeRepository
+	grades map[int]*domain.Grade
+}
+
+func (r *fakeGradeRepo) GetByID(id int) (*domain.Grade, error) {
+	grade, ok := r.grades[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *grade
+	return &copied, nil
+}
+
+func (r *fakeGradeRepo) GetByStudentID(studentID int, termID int) ([]*domain.Grade, error) {
+	var grades []*domain.Grade
+	for _, grade := range r.grades {
+		if grade.StudentID == studentID && (termID == 0 || grade.TermID == termID) {
+			grades = append(grades, grade)
+		}
+	}
+	return grades, nil
+}
+
+func (r *fakeGradeRepo) Update(grade *domain.Grade) error {
+	copied := *grade
+	r.grades[grade.ID] = &copied
+	return nil
+}
+
+func (r *fakeGradeRepo) WithTx(tx *database.Database) repository.IGradeRepository {
+	return r
+}
+
+type fakeTeachingAssignmentRepo struct {
+	repository.ITeachingAssignmentRepository
+	// courses maps each professor to the courses they teach.
+	courses map[int][]int
+}
+
+func (r *fakeTeachingAssignmentRepo) Exists(professorID, courseID int) (bool, error) {
+	for _, id := range r.courses[professorID] {
+		if id == courseID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeStudentRepo struct {
+	repository.IStudentRepository
+	students map[int]*domain.Student
+}
+
+func (r *fakeStudentRepo) GetByID(id int) (*domain.Student, error) {
+	return r.students[id], nil
+}
+
+type fakeTermRepo struct {
+	repository.ITermRepository
+}
+
+func (r *fakeTermRepo) GetByID(id int) (*domain.Term, error) {
+	return &domain.Term{ID: id}, nil
+}
+
+type fakeAuditRepo struct {
+	repository.IAuditRepository
+	entries []*domain.AuditEntry
+}
+
+func (r *fakeAuditRepo) Create(entry *domain.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeAuditRepo) WithTx(tx *database.Database) repository.IAuditRepository {
+	return r
+}
+
+// fakeTransactor runs the work without a transaction; the fake repositories
+// ignore the one they are bound to.
+type fakeTransactor struct{}
+
+func (fakeTransactor) Transaction(fn func(tx *database.Database) error) error {
+	return fn(nil)
+}
+
+func TestGradeUsecaseUpdate(t *testing.T) {
+	// Professor 1 teaches course 10 and professor 2 teaches courses 20 and 30.
+	// Grade 100 was given in course 20.
+	tests := []struct {
+		name  string
+		actor *domain.Principal
+		grade domain.Grade
+		err   error
+	}{
+		{"professor of the course", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 2}, domain.Grade{ID: 100, StudentID: 5, CourseID: 20, Grade: 90}, nil},
+		{"moved to another course of the professor", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 2}, domain.Grade{ID: 100, StudentID: 5, CourseID: 30, Grade: 90}, nil},
+		{"taken over into the caller's course", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 1}, domain.Grade{ID: 100, StudentID: 5, CourseID: 10, Grade: 90}, domain.ErrProfessorNotAssigned},
+		{"grade of another professor's course", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 1}, domain.Grade{ID: 100, StudentID: 5, CourseID: 20, Grade: 90}, domain.ErrProfessorNotAssigned},
+		{"admin for a professor of the course", &domain.Principal{Role: domain.RoleAdmin}, domain.Grade{ID: 100, StudentID: 5, CourseID: 20, ProfessorID: 2, Grade: 90}, nil},
+		{"admin moving it to another professor's course", &domain.Principal{Role: domain.RoleAdmin}, domain.Grade{ID: 100, StudentID: 5, CourseID: 10, ProfessorID: 1, Grade: 90}, domain.ErrProfessorNotAssigned},
+		{"unknown grade", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 2}, domain.Grade{ID: 101, StudentID: 5, CourseID: 20, Grade: 90}, domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := &domain.Grade{ID: 100, StudentID: 5, CourseID: 20, ProfessorID: 2, TermID: 1, Grade: 60}
+			grades := &fakeGradeRepo{grades: map[int]*domain.Grade{100: stored}}
+			uc := &GradeUsecase{
+				GradeRepo:              grades,
+				TeachingAssignmentRepo: &fakeTeachingAssignmentRepo{courses: map[int][]int{1: {10}, 2: {20, 30}}},
+				TermRepo:               &fakeTermRepo{},
+				GradingScaleRepo:       &fakeGradingScaleRepo{},
+				StudentRepo:            &fakeStudentRepo{students: map[int]*domain.Student{5: {ID: 5, Status: domain.StudentStatusActive}}},
+				AuditRepo:              &fakeAuditRepo{},
+				Transactor:             fakeTransactor{},
+			}
+
+			grade := tt.grade
+			err := uc.Update(tt.actor, &grade)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Update() = %v, want %v", err, tt.err)
+			}
+			if err != nil && *grades.grades[100] != *stored {
+				t.Errorf("stored grade = %+v after a rejected update, want %+v", grades.grades[100], stored)
+			}
+		})
+	}
+}
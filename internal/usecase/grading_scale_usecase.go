@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type IGradingScaleUsecase interface {
+	GetAll() ([]*domain.GradingScale, error)
+	GetByID(id string) (*domain.GradingScale, error)
+	Create(actor *domain.Principal, scale *domain.GradingScale) error
+	Update(actor *domain.Principal, scale *domain.GradingScale) error
+	Delete(actor *domain.Principal, id string) error
+}
+
+type GradingScaleUsecase struct {
+	GradingScaleRepo repository.IGradingScaleRepository
+	AuditRepo        repository.IAuditRepository
+	Transactor       database.ITransactor
+}
+
+var (
+	gradingScaleUsecaseInstance *GradingScaleUsecase
+	gradingScaleUsecaseOnce     sync.Once
+)
+
+func NewGradingScaleUsecase(repo repository.IGradingScaleRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IGradingScaleUsecase {
+	gradingScaleUsecaseOnce.Do(func() {
+		gradingScaleUsecaseInstance = &GradingScaleUsecase{
+			GradingScaleRepo: repo,
+			AuditRepo:        auditRepo,
+			Transactor:       transactor,
+		}
+	})
+	return gradingScaleUsecaseInstance
+}
+
+func (uc *GradingScaleUsecase) GetAll() ([]*domain.GradingScale, error) {
+	return uc.GradingScaleRepo.GetAll()
+}
+
+func (uc *GradingScaleUsecase) GetByID(id string) (*domain.GradingScale, error) {
+	scaleID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := uc.GradingScaleRepo.GetByID(scaleID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return scale, nil
+}
+
+func (uc *GradingScaleUsecase) Create(actor *domain.Principal, scale *domain.GradingScale) error {
+	if err := scale.Validate(); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradingScaleRepo.WithTx(tx).Create(scale); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityScale, scale.ID, nil, scale)
+	})
+}
+
+// Update changes a scale. Grades already recorded are not checked again.
+func (uc *GradingScaleUsecase) Update(actor *domain.Principal, scale *domain.GradingScale) error {
+	if err := scale.Validate(); err != nil {
+		return err
+	}
+
+	before, err := uc.GradingScaleRepo.GetByID(scale.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradingScaleRepo.WithTx(tx).Update(scale); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityScale, scale.ID, before, scale)
+	})
+}
+
+// Delete removes a scale no course uses.
+func (uc *GradingScaleUsecase) Delete(actor *domain.Principal, id string) error {
+	scaleID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.GradingScaleRepo.GetByID(scaleID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	used, err := uc.GradingScaleRepo.IsUsed(scaleID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.GradingScaleRepo.WithTx(tx).Delete(scaleID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityScale, scaleID, before, nil)
+	})
+}
+
+// describeGrades fills in the letter and pass/fail status of grades from
+// the grading scales of their courses. Grades of courses without a scale are
+// left as they are.
+func describeGrades(scaleRepo repository.IGradingScaleRepository, grades ...*domain.Grade) error {
+	scales := make(map[int]*domain.GradingScale)
+	for _, grade := range grades {
+		scale, ok := scales[grade.CourseID]
+		if !ok {
+			var err error
+			scale, err = scaleRepo.GetByCourseID(grade.CourseID)
+			if err != nil {
+				return notFoundIfNoRows(err)
+			}
+			scales[grade.CourseID] = scale
+		}
+		if scale == nil {
+			continue
+		}
+
+		gradeRange, err := scale.Classify(grade.Grade)
+		if err != nil {
+			// Recorded before the scale changed; show the number alone.
+			continue
+		}
+		passed := gradeRange.Passing
+		grade.Letter = gradeRange.Letter
+		grade.Passed = &passed
+	}
+	return nil
+}
+
+// checkGradeScale rejects a grade outside its course's grading scale and
+// fills in its letter and pass/fail status.
+func checkGradeScale(scaleRepo repository.IGradingScaleRepository, grade *domain.Grade) error {
+	scale, err := scaleRepo.GetByCourseID(grade.CourseID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+	if scale == nil {
+		grade.Letter, grade.Passed = "", nil
+		return nil
+	}
+
+	gradeRange, err := scale.Classify(grade.Grade)
+	if err != nil {
+		return err
+	}
+	passed := gradeRange.Passing
+	grade.Letter = gradeRange.Letter
+	grade.Passed = &passed
+	return nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"golang-technical-test/config"
+	"golang-technical-test/internal/repository"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ILoginThrottleUsecase interface {
+	Check(username, ip string) (time.Duration, error)
+	RegisterFailure(username, ip string) error
+	RegisterSuccess(username string) error
+}
+
+type LoginThrottleUsecase struct {
+	LoginAttemptRepo repository.ILoginAttemptRepository
+	Config           *config.LoginThrottleConfig
+}
+
+var (
+	loginThrottleUsecaseInstance *LoginThrottleUsecase
+	loginThrottleUsecaseOnce     sync.Once
+)
+
+func NewLoginThrottleUsecase(repo repository.ILoginAttemptRepository, cfg *config.LoginThrottleConfig) ILoginThrottleUsecase {
+	loginThrottleUsecaseOnce.Do(func() {
+		loginThrottleUsecaseInstance = &LoginThrottleUsecase{
+			LoginAttemptRepo: repo,
+			Config:           cfg,
+		}
+	})
+	return loginThrottleUsecaseInstance
+}
+
+// Check returns how long the caller must wait before trying to log in again,
+// or zero when the attempt may go ahead.
+func (uc *LoginThrottleUsecase) Check(username, ip string) (time.Duration, error) {
+	now := time.Now()
+
+	userWait, err := uc.wait(usernameKey(username), now)
+	if err != nil {
+		return 0, err
+	}
+	ipWait, err := uc.wait(ipKey(ip), now)
+	if err != nil {
+		return 0, err
+	}
+
+	if ipWait > userWait {
+		return ipWait, nil
+	}
+	return userWait, nil
+}
+
+func (uc *LoginThrottleUsecase) RegisterFailure(username, ip string) error {
+	now := time.Now()
+
+	if err := uc.recordFailure(usernameKey(username), uc.Config.MaxFailures, now); err != nil {
+		return err
+	}
+	return uc.recordFailure(ipKey(ip), uc.Config.IPMaxFailures, now)
+}
+
+// RegisterSuccess clears the failures of the username. The IP counter is
+// kept, so one valid account can't be used to reset it.
+func (uc *LoginThrottleUsecase) RegisterSuccess(username string) error {
+	return uc.LoginAttemptRepo.Reset(usernameKey(username))
+}
+
+func (uc *LoginThrottleUsecase) wait(key string, now time.Time) (time.Duration, error) {
+	attempts, err := uc.LoginAttemptRepo.Get(key)
+	if err != nil || attempts == nil {
+		return 0, err
+	}
+
+	if now.Before(attempts.LockedUntil) {
+		return attempts.LockedUntil.Sub(now), nil
+	}
+
+	if attempts.Failures < uc.Config.DelayAfter || now.Sub(attempts.LastFailure) > uc.Config.FailureWindow {
+		return 0, nil
+	}
+
+	nextAllowed := attempts.LastFailure.Add(uc.delay(attempts.Failures))
+	if now.Before(nextAllowed) {
+		return nextAllowed.Sub(now), nil
+	}
+	return 0, nil
+}
+
+// delay doubles BaseDelay for every failure past DelayAfter, up to MaxDelay.
+func (uc *LoginThrottleUsecase) delay(failures int) time.Duration {
+	delay := uc.Config.BaseDelay
+	for i := uc.Config.DelayAfter; i < failures && delay < uc.Config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > uc.Config.MaxDelay {
+		delay = uc.Config.MaxDelay
+	}
+	return delay
+}
+
+func (uc *LoginThrottleUsecase) recordFailure(key string, maxFailures int, now time.Time) error {
+	previous, err := uc.LoginAttemptRepo.Get(key)
+	if err != nil {
+		return err
+	}
+	// Old failures, or the ones that led to an expired lockout, no longer count.
+	if previous != nil && (now.Sub(previous.LastFailure) > uc.Config.FailureWindow ||
+		(!previous.LockedUntil.IsZero() && !now.Before(previous.LockedUntil))) {
+		if err := uc.LoginAttemptRepo.Reset(key); err != nil {
+			return err
+		}
+	}
+
+	attempts, err := uc.LoginAttemptRepo.RecordFailure(key, now)
+	if err != nil {
+		return err
+	}
+
+	if maxFailures > 0 && attempts.Failures >= maxFailures {
+		return uc.LoginAttemptRepo.Lock(key, now.Add(uc.Config.LockoutDuration))
+	}
+	return nil
+}
+
+func usernameKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"golang-technical-test/config"
+	"golang-technical-test/internal/domain"
+	"testing"
+	"time"
+)
+
+type fakeLoginAttemptRepo struct {
+	attempts map[string]*domain.LoginAttempts
+}
+
+func newFakeLoginAttemptRepo() *fakeLoginAttemptRepo {
+	return &fakeLoginAttemptRepo{attempts: make(map[string]*domain.LoginAttempts)}
+}
+
+func (r *fakeLoginAttemptRepo) Get(key string) (*domain.LoginAttempts, error) {
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempts
+	return &copied, nil
+}
+
+func (r *fakeLoginAttemptRepo) RecordFailure(key string, at time.Time) (*domain.LoginAttempts, error) {
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{}
+		r.attempts[key] = attempts
+	}
+	attempts.Failures++
+	attempts.LastFailure = at
+	copied := *attempts
+	return &copied, nil
+}
+
+func (r *fakeLoginAttemptRepo) Lock(key string, until time.Time) error {
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{}
+		r.attempts[key] = attempts
+	}
+	attempts.LockedUntil = until
+	return nil
+}
+
+func (r *fakeLoginAttemptRepo) Reset(key string) error {
+	delete(r.attempts, key)
+	return nil
+}
+
+func newTestLoginThrottle() *LoginThrottleUsecase {
+	return &LoginThrottleUsecase{
+		LoginAttemptRepo: newFakeLoginAttemptRepo(),
+		Config: &config.LoginThrottleConfig{
+			DelayAfter:      3,
+			BaseDelay:       time.Second,
+			MaxDelay:        8 * time.Second,
+			MaxFailures:     10,
+			IPMaxFailures:   50,
+			LockoutDuration: 15 * time.Minute,
+			FailureWindow:   15 * time.Minute,
+		},
+	}
+}
+
+func TestLoginThrottleDelay(t *testing.T) {
+	uc := newTestLoginThrottle()
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{5, 4 * time.Second},
+		{6, 8 * time.Second},
+		{9, 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := uc.delay(tt.failures); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginThrottleWait(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		attempts *domain.LoginAttempts
+		want     time.Duration
+	}{
+		{"no failures", nil, 0},
+		{"below DelayAfter", &domain.LoginAttempts{Failures: 2, LastFailure: now}, 0},
+		{"right after a failure", &domain.LoginAttempts{Failures: 4, LastFailure: now}, 2 * time.Second},
+		{"part of the delay elapsed", &domain.LoginAttempts{Failures: 4, LastFailure: now.Add(-500 * time.Millisecond)}, 1500 * time.Millisecond},
+		{"delay elapsed", &domain.LoginAttempts{Failures: 4, LastFailure: now.Add(-3 * time.Second)}, 0},
+		{"failures outside the window", &domain.LoginAttempts{Failures: 9, LastFailure: now.Add(-16 * time.Minute)}, 0},
+		{"locked", &domain.LoginAttempts{Failures: 10, LastFailure: now, LockedUntil: now.Add(time.Minute)}, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestLoginThrottle()
+			if tt.attempts != nil {
+				uc.LoginAttemptRepo.(*fakeLoginAttemptRepo).attempts["user:alice"] = tt.attempts
+			}
+
+			got, err := uc.wait("user:alice", now)
+			if err != nil {
+				t.Fatalf("wait() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginThrottleLockout(t *testing.T) {
+	uc := newTestLoginThrottle()
+	repo := uc.LoginAttemptRepo.(*fakeLoginAttemptRepo)
+	now := time.Now()
+
+	for i := 0; i < uc.Config.MaxFailures; i++ {
+		if err := uc.recordFailure("user:alice", uc.Config.MaxFailures, now); err != nil {
+			t.Fatalf("recordFailure() error = %v", err)
+		}
+	}
+	if want := now.Add(uc.Config.LockoutDuration); !repo.attempts["user:alice"].LockedUntil.Equal(want) {
+		t.Fatalf("LockedUntil = %v, want %v", repo.attempts["user:alice"].LockedUntil, want)
+	}
+
+	// The first failure after the lockout starts a new count.
+	after := now.Add(uc.Config.LockoutDuration + time.Second)
+	if err := uc.recordFailure("user:alice", uc.Config.MaxFailures, after); err != nil {
+		t.Fatalf("recordFailure() error = %v", err)
+	}
+	if attempts := repo.attempts["user:alice"]; attempts.Failures != 1 || !attempts.LockedUntil.IsZero() {
+		t.Errorf("attempts after the lockout = %+v, want 1 failure and no lock", attempts)
+	}
+
+	// So does the first failure after FailureWindow.
+	if err := uc.recordFailure("user:alice", uc.Config.MaxFailures, after.Add(uc.Config.FailureWindow+time.Second)); err != nil {
+		t.Fatalf("recordFailure() error = %v", err)
+	}
+	if failures := repo.attempts["user:alice"].Failures; failures != 1 {
+		t.Errorf("failures after the window = %d, want 1", failures)
+	}
+}
+
+func TestLoginThrottleRegisterSuccess(t *testing.T) {
+	uc := newTestLoginThrottle()
+	repo := uc.LoginAttemptRepo.(*fakeLoginAttemptRepo)
+
+	for i := 0; i < 3; i++ {
+		if err := uc.RegisterFailure("Alice", "10.0.0.1"); err != nil {
+			t.Fatalf("RegisterFailure() error = %v", err)
+		}
+	}
+	if err := uc.RegisterSuccess("alice"); err != nil {
+		t.Fatalf("RegisterSuccess() error = %v", err)
+	}
+
+	if _, ok := repo.attempts["user:alice"]; ok {
+		t.Error("username failures kept after a successful login")
+	}
+	if attempts := repo.attempts["ip:10.0.0.1"]; attempts == nil || attempts.Failures != 3 {
+		t.Errorf("IP failures = %+v, want 3", attempts)
+	}
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"fmt"
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type IMeetingUsecase interface {
+	GetByCourseID(courseID string, termID string) ([]*domain.Meeting, error)
+	Create(actor *domain.Principal, courseID string, meeting *domain.Meeting) error
+	Update(actor *domain.Principal, courseID string, meeting *domain.Meeting) error
+	Delete(actor *domain.Principal, courseID string, meetingID string) error
+	GetSchedule(actor *domain.Principal, studentID string, termID string) (*domain.Schedule, error)
+}
+
+type MeetingUsecase struct {
+	MeetingRepo    repository.IMeetingRepository
+	CourseRepo     repository.ICourseRepository
+	SectionRepo    repository.ISectionRepository
+	RoomRepo       repository.IRoomRepository
+	EnrollmentRepo repository.IEnrollmentRepository
+	TermRepo       repository.ITermRepository
+	AuditRepo      repository.IAuditRepository
+	Transactor     database.ITransactor
+}
+
+var (
+	meetingUsecaseInstance *MeetingUsecase
+	meetingUsecaseOnce     sync.Once
+)
+
+func NewMeetingUsecase(repo repository.IMeetingRepository, courseRepo repository.ICourseRepository, sectionRepo repository.ISectionRepository, roomRepo repository.IRoomRepository, enrollmentRepo repository.IEnrollmentRepository, termRepo repository.ITermRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IMeetingUsecase {
+	meetingUsecaseOnce.Do(func() {
+		meetingUsecaseInstance = &MeetingUsecase{
+			MeetingRepo:    repo,
+			CourseRepo:     courseRepo,
+			SectionRepo:    sectionRepo,
+			RoomRepo:       roomRepo,
+			EnrollmentRepo: enrollmentRepo,
+			TermRepo:       termRepo,
+			AuditRepo:      auditRepo,
+			Transactor:     transactor,
+		}
+	})
+	return meetingUsecaseInstance
+}
+
+// withTx returns a copy of uc whose rooms, meetings and audit entries are
+// read and written in tx.
+func (uc *MeetingUsecase) withTx(tx *database.Database) *MeetingUsecase {
+	bound := *uc
+	bound.MeetingRepo = uc.MeetingRepo.WithTx(tx)
+	bound.RoomRepo = uc.RoomRepo.WithTx(tx)
+	bound.AuditRepo = uc.AuditRepo.WithTx(tx)
+	return &bound
+}
+
+func (uc *MeetingUsecase) GetByCourseID(courseID string, termID string) ([]*domain.Meeting, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	return uc.MeetingRepo.GetByCourseID(intCourseID, intTermID)
+}
+
+// Create adds a weekly meeting to the course. The term defaults to the
+// section's term, or else to the active term. See checkRoom for the room.
+func (uc *MeetingUsecase) Create(actor *domain.Principal, courseID string, meeting *domain.Meeting) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	meeting.CourseID = intCourseID
+
+	if err := meeting.Validate(); err != nil {
+		return err
+	}
+	if _, err := uc.CourseRepo.GetByID(meeting.CourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	var section *domain.Section
+	if meeting.SectionID != nil {
+		section, err = uc.SectionRepo.GetByID(*meeting.SectionID)
+		if err != nil {
+			return notFoundIfNoRows(err)
+		}
+		if meeting.TermID == 0 {
+			meeting.TermID = section.TermID
+		}
+	}
+	if meeting.TermID, err = resolveTerm(uc.TermRepo, meeting.TermID); err != nil {
+		return err
+	}
+	if section != nil && (section.CourseID != meeting.CourseID || section.TermID != meeting.TermID) {
+		return domain.ErrSectionMismatch
+	}
+
+	var warnings []string
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		var err error
+		if warnings, err = uc.checkRoom(meeting); err != nil {
+			return err
+		}
+		if err := uc.MeetingRepo.Create(meeting); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo, actor, domain.AuditActionCreate, domain.AuditEntityMeeting, meeting.ID, nil, meeting)
+	})
+	if err != nil {
+		return err
+	}
+	meeting.Warnings = warnings
+	return nil
+}
+
+// Update changes the day, times and room of a meeting; its course, term
+// and section stay. See checkRoom for the room.
+func (uc *MeetingUsecase) Update(actor *domain.Principal, courseID string, meeting *domain.Meeting) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+
+	if err := meeting.Validate(); err != nil {
+		return err
+	}
+	before, err := uc.meeting(intCourseID, meeting.ID)
+	if err != nil {
+		return err
+	}
+	meeting.CourseID, meeting.TermID, meeting.SectionID = before.CourseID, before.TermID, before.SectionID
+
+	var warnings []string
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		uc := uc.withTx(tx)
+		var err error
+		if warnings, err = uc.checkRoom(meeting); err != nil {
+			return err
+		}
+		if err := uc.MeetingRepo.Update(meeting); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo, actor, domain.AuditActionUpdate, domain.AuditEntityMeeting, meeting.ID, before, meeting)
+	})
+	if err != nil {
+		return err
+	}
+	meeting.Warnings = warnings
+	return nil
+}
+
+// checkRoom rejects a meeting in a room booked by another meeting at an
+// overlapping time in the term. A room with fewer seats than the section,
+// or than all the course's sections for a meeting of the whole course, is
+// allowed but returns a warning. The room stays locked until the
+// transaction uc is bound to ends, so two meetings can't book it at once.
+func (uc *MeetingUsecase) checkRoom(meeting *domain.Meeting) ([]string, error) {
+	if meeting.RoomID == nil {
+		return nil, nil
+	}
+	room, err := uc.RoomRepo.Lock(*meeting.RoomID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	booking, err := roomBooking(uc.MeetingRepo, room.ID, meeting)
+	if err != nil {
+		return nil, err
+	}
+	if booking != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrRoomBooked, booking)
+	}
+
+	var sections []*domain.Section
+	if meeting.SectionID != nil {
+		section, err := uc.SectionRepo.GetByID(*meeting.SectionID)
+		if err != nil {
+			return nil, notFoundIfNoRows(err)
+		}
+		sections = append(sections, section)
+	} else if sections, err = uc.SectionRepo.GetByCourseID(meeting.CourseID, meeting.TermID); err != nil {
+		return nil, err
+	}
+	seats := 0
+	for _, section := range sections {
+		seats += section.Capacity
+	}
+	if seats > room.Capacity {
+		return []string{fmt.Sprintf("room %s has %d seats but the meeting is for up to %d students", room.Name, room.Capacity, seats)}, nil
+	}
+	return nil, nil
+}
+
+func (uc *MeetingUsecase) Delete(actor *domain.Principal, courseID string, meetingID string) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	intMeetingID, err := strconv.Atoi(meetingID)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.meeting(intCourseID, intMeetingID)
+	if err != nil {
+		return err
+	}
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.MeetingRepo.WithTx(tx).Delete(intMeetingID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityMeeting, intMeetingID, before, nil)
+	})
+}
+
+// GetSchedule returns the student's weekly timetable in the term, the
+// active one by default, sorted by day and time.
+func (uc *MeetingUsecase) GetSchedule(actor *domain.Principal, studentID string, termID string) (*domain.Schedule, error) {
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+	if intTermID, err = resolveTerm(uc.TermRepo, intTermID); err != nil {
+		return nil, err
+	}
+
+	enrollments, err := uc.EnrollmentRepo.GetByStudentID(intStudentID, intTermID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &domain.Schedule{StudentID: intStudentID, TermID: intTermID, Meetings: []*domain.ScheduleEntry{}}
+	for _, enrollment := range enrollments {
+		meetings, err := enrollmentMeetings(uc.MeetingRepo, enrollment)
+		if err != nil {
+			return nil, err
+		}
+		if len(meetings) == 0 {
+			continue
+		}
+		course, err := uc.CourseRepo.GetByID(enrollment.CourseID)
+		if err != nil {
+			return nil, notFoundIfNoRows(err)
+		}
+		for _, meeting := range meetings {
+			entry := &domain.ScheduleEntry{Meeting: meeting, CourseName: course.Name, Status: enrollment.Status}
+			if meeting.RoomID != nil {
+				room, err := uc.RoomRepo.GetByID(*meeting.RoomID)
+				if err != nil {
+					return nil, notFoundIfNoRows(err)
+				}
+				entry.RoomName = room.Name
+			}
+			schedule.Meetings = append(schedule.Meetings, entry)
+		}
+	}
+
+	day := make(map[string]int, len(domain.Weekdays))
+	for i, d := range domain.Weekdays {
+		day[d] = i
+	}
+	sort.SliceStable(schedule.Meetings, func(i, j int) bool {
+		a, b := schedule.Meetings[i], schedule.Meetings[j]
+		if a.Day != b.Day {
+			return day[a.Day] < day[b.Day]
+		}
+		return a.StartTime < b.StartTime
+	})
+	return schedule, nil
+}
+
+// meeting returns the meeting, or ErrNotFound when it is not part of the
+// course.
+func (uc *MeetingUsecase) meeting(courseID, id int) (*domain.Meeting, error) {
+	meeting, err := uc.MeetingRepo.GetByID(id)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if meeting.CourseID != courseID {
+		return nil, domain.ErrNotFound
+	}
+	return meeting, nil
+}
+
+// enrollmentMeetings returns the meetings the enrolled student attends: the
+// course's meetings in the term for everyone, and those of the student's
+// section.
+func enrollmentMeetings(meetingRepo repository.IMeetingRepository, enrollment *domain.Enrollment) ([]*domain.Meeting, error) {
+	meetings, err := meetingRepo.GetByCourseID(enrollment.CourseID, enrollment.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	var attended []*domain.Meeting
+	for _, meeting := range meetings {
+		if meeting.SectionID == nil || (enrollment.SectionID != nil && *meeting.SectionID == *enrollment.SectionID) {
+			attended = append(attended, meeting)
+		}
+	}
+	return attended, nil
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"golang-technical-test/config"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recoveryCodeCount is how many recovery codes are handed out at once.
+const recoveryCodeCount = 10
+
+type IMFAUsecase interface {
+	LoginStep(user *domain.User) (string, error)
+	Enroll(actor *domain.Principal) (string, string, error)
+	Activate(actor *domain.Principal, code string) ([]string, error)
+	RegenerateRecoveryCodes(actor *domain.Principal, code string) ([]string, error)
+	Disable(actor *domain.Principal, code string) error
+	Reset(userID string) error
+	Verify(userID int, code string) (*domain.User, error)
+}
+
+type MFAUsecase struct {
+	TOTPRepo         repository.ITOTPRepository
+	RecoveryCodeRepo repository.IRecoveryCodeRepository
+	UserRepo         repository.IUserRepository
+	Config           *config.MFAConfig
+}
+
+var (
+	mfaUsecaseInstance *MFAUsecase
+	mfaUsecaseOnce     sync.Once
+)
+
+func NewMFAUsecase(totpRepo repository.ITOTPRepository, recoveryCodeRepo repository.IRecoveryCodeRepository, userRepo repository.IUserRepository, cfg *config.MFAConfig) IMFAUsecase {
+	mfaUsecaseOnce.Do(func() {
+		mfaUsecaseInstance = &MFAUsecase{
+			TOTPRepo:         totpRepo,
+			RecoveryCodeRepo: recoveryCodeRepo,
+			UserRepo:         userRepo,
+			Config:           cfg,
+		}
+	})
+	return mfaUsecaseInstance
+}
+
+// LoginStep returns the step that follows a valid password for the user:
+// domain.MFAStepVerify with two-factor authentication on, domain.MFAStepEnroll
+// for staff who have not turned it on yet, and domain.MFAStepNone otherwise.
+func (uc *MFAUsecase) LoginStep(user *domain.User) (string, error) {
+	enabled, err := uc.isEnabled(user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case enabled:
+		return domain.MFAStepVerify, nil
+	case user.IsStaff():
+		return domain.MFAStepEnroll, nil
+	default:
+		return domain.MFAStepNone, nil
+	}
+}
+
+// isEnabled reports whether the user has confirmed a second factor.
+func (uc *MFAUsecase) isEnabled(userID int) (bool, error) {
+	credential, err := uc.TOTPRepo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return credential != nil && credential.Enabled, nil
+}
+
+// Enroll creates a new TOTP secret for the caller and returns it with its
+// otpauth URI. It has no effect on logins until it is activated.
+func (uc *MFAUsecase) Enroll(actor *domain.Principal) (string, string, error) {
+	if actor.UserID == 0 || !actor.IsStaff() {
+		return "", "", domain.ErrForbidden
+	}
+
+	enabled, err := uc.isEnabled(actor.UserID)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", domain.ErrConflict
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	credential := &domain.TOTPCredential{UserID: actor.UserID, Secret: secret}
+	if err := uc.TOTPRepo.Save(credential); err != nil {
+		return "", "", err
+	}
+
+	return secret, utils.TOTPURI(uc.Config.Issuer, actor.Username, secret), nil
+}
+
+// Activate turns on two-factor authentication once the caller sends a valid
+// code for the enrolled secret, and returns the first set of recovery codes.
+func (uc *MFAUsecase) Activate(actor *domain.Principal, code string) ([]string, error) {
+	credential, err := uc.TOTPRepo.GetByUserID(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		return nil, domain.ErrNotFound
+	}
+	if credential.Enabled {
+		return nil, domain.ErrConflict
+	}
+
+	if err := uc.checkTOTP(credential, code); err != nil {
+		return nil, err
+	}
+
+	if err := uc.TOTPRepo.Enable(actor.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.newRecoveryCodes(actor.UserID)
+}
+
+// RegenerateRecoveryCodes replaces the caller's recovery codes. It needs a
+// current code from the authenticator app.
+func (uc *MFAUsecase) RegenerateRecoveryCodes(actor *domain.Principal, code string) ([]string, error) {
+	credential, err := uc.enabledCredential(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkTOTP(credential, code); err != nil {
+		return nil, err
+	}
+
+	return uc.newRecoveryCodes(actor.UserID)
+}
+
+// Disable turns off two-factor authentication for the caller, who must prove
+// it still holds the second factor.
+func (uc *MFAUsecase) Disable(actor *domain.Principal, code string) error {
+	if _, err := uc.verifyCode(actor.UserID, code); err != nil {
+		return err
+	}
+
+	return uc.remove(actor.UserID)
+}
+
+// Reset removes the second factor of a user who lost it, so they can log in
+// with their password and enroll again. Staff users are made to enroll before
+// their next session starts.
+func (uc *MFAUsecase) Reset(userID string) error {
+	intID, err := strconv.Atoi(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.UserRepo.GetByID(intID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrNotFound
+	}
+
+	return uc.remove(user.ID)
+}
+
+// Verify checks the second login step: a code from the authenticator app or
+// an unused recovery code. It returns the user to issue tokens for.
+func (uc *MFAUsecase) Verify(userID int, code string) (*domain.User, error) {
+	user, err := uc.UserRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if _, err := uc.verifyCode(userID, code); err != nil {
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, domain.ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func (uc *MFAUsecase) verifyCode(userID int, code string) (*domain.TOTPCredential, error) {
+	credential, err := uc.enabledCredential(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == utils.TOTPDigits {
+		return credential, uc.checkTOTP(credential, code)
+	}
+
+	used, err := uc.RecoveryCodeRepo.Use(userID, utils.HashToken(normalizeRecoveryCode(code)), time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	return credential, nil
+}
+
+func (uc *MFAUsecase) enabledCredential(userID int) (*domain.TOTPCredential, error) {
+	credential, err := uc.TOTPRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil || !credential.Enabled {
+		return nil, domain.ErrNotFound
+	}
+
+	return credential, nil
+}
+
+// checkTOTP accepts each code only once, even within its validity window.
+func (uc *MFAUsecase) checkTOTP(credential *domain.TOTPCredential, code string) error {
+	step, ok := utils.ValidateTOTP(credential.Secret, code, time.Now())
+	if !ok || step <= credential.LastStep {
+		return domain.ErrInvalidMFACode
+	}
+
+	updated, err := uc.TOTPRepo.UpdateLastStep(credential.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func (uc *MFAUsecase) newRecoveryCodes(userID int) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = utils.HashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := uc.RecoveryCodeRepo.Replace(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (uc *MFAUsecase) remove(userID int) error {
+	if err := uc.TOTPRepo.Delete(userID); err != nil {
+		return err
+	}
+
+	return uc.RecoveryCodeRepo.DeleteByUserID(userID)
+}
+
+// generateRecoveryCode returns a code such as "k3jd9-x8q2m", easy to copy by hand.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
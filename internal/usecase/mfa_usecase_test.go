@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/utils"
+	"testing"
+	"time"
+)
+
+type fakeTOTPRepo struct {
+	repository.ITOTPRepository
+	credential *domain.TOTPCredential
+	lastStep   int64
+}
+
+func (r *fakeTOTPRepo) GetByUserID(userID int) (*domain.TOTPCredential, error) {
+	if r.credential == nil || r.credential.UserID != userID {
+		return nil, nil
+	}
+	return r.credential, nil
+}
+
+func (r *fakeTOTPRepo) UpdateLastStep(userID int, step int64) (bool, error) {
+	if step <= r.lastStep {
+		return false, nil
+	}
+	r.lastStep = step
+	return true, nil
+}
+
+func TestMFACheckTOTP(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	current := utils.TOTPStep(time.Now())
+	code := func(step int64) string {
+		c, err := utils.TOTPCode(secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode() error = %v", err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		lastStep int64
+		code     string
+		err      error
+	}{
+		{"current code", 0, code(current), nil},
+		{"previous period", 0, code(current - 1), nil},
+		{"next period", 0, code(current + 1), nil},
+		{"outside the window", 0, code(current - 3), domain.ErrInvalidMFACode},
+		{"wrong code", 0, "abcdef", domain.ErrInvalidMFACode},
+		{"replayed", current, code(current), domain.ErrInvalidMFACode},
+		{"older than the last accepted code", current, code(current - 1), domain.ErrInvalidMFACode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &MFAUsecase{TOTPRepo: &fakeTOTPRepo{lastStep: tt.lastStep}}
+			credential := &domain.TOTPCredential{UserID: 1, Secret: secret, Enabled: true, LastStep: tt.lastStep}
+
+			if err := uc.checkTOTP(credential, tt.code); !errors.Is(err, tt.err) {
+				t.Errorf("checkTOTP() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	// Two requests that read the credential before either stored its step
+	// can't both use the same code.
+	uc := &MFAUsecase{TOTPRepo: &fakeTOTPRepo{}}
+	first := &domain.TOTPCredential{UserID: 1, Secret: secret, Enabled: true}
+	second := *first
+	if err := uc.checkTOTP(first, code(current)); err != nil {
+		t.Fatalf("checkTOTP() = %v, want nil", err)
+	}
+	if err := uc.checkTOTP(&second, code(current)); !errors.Is(err, domain.ErrInvalidMFACode) {
+		t.Errorf("checkTOTP() of the same code again = %v, want %v", err, domain.ErrInvalidMFACode)
+	}
+}
+
+func TestMFALoginStep(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		credential *domain.TOTPCredential
+		step       string
+	}{
+		{"student without a second factor", domain.RoleStudent, nil, domain.MFAStepNone},
+		{"student with a second factor", domain.RoleStudent, &domain.TOTPCredential{UserID: 1, Enabled: true}, domain.MFAStepVerify},
+		{"admin without a second factor", domain.RoleAdmin, nil, domain.MFAStepEnroll},
+		{"registrar without a second factor", domain.RoleRegistrar, nil, domain.MFAStepEnroll},
+		{"professor enrolled but not activated", domain.RoleProfessor, &domain.TOTPCredential{UserID: 1}, domain.MFAStepEnroll},
+		{"professor with a second factor", domain.RoleProfessor, &domain.TOTPCredential{UserID: 1, Enabled: true}, domain.MFAStepVerify},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &MFAUsecase{TOTPRepo: &fakeTOTPRepo{credential: tt.credential}}
+
+			step, err := uc.LoginStep(&domain.User{ID: 1, Role: tt.role})
+			if err != nil {
+				t.Fatalf("LoginStep() error = %v", err)
+			}
+			if step != tt.step {
+				t.Errorf("LoginStep() = %q, want %q", step, tt.step)
+			}
+		})
+	}
+}
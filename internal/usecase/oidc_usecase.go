@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/oidc"
+	"golang-technical-test/utils"
+	"sync"
+	"time"
+)
+
+// oidcLoginTTL is how long the user has to log in at the provider.
+const oidcLoginTTL = 10 * time.Minute
+
+type IOIDCUsecase interface {
+	BeginLogin(ctx context.Context) (string, error)
+	CompleteLogin(ctx context.Context, state, code string) (*domain.User, error)
+}
+
+type OIDCUsecase struct {
+	Client           *oidc.Client
+	OIDCStateRepo    repository.IOIDCStateRepository
+	UserIdentityRepo repository.IUserIdentityRepository
+	UserRepo         repository.IUserRepository
+}
+
+var (
+	oidcUsecaseInstance *OIDCUsecase
+	oidcUsecaseOnce     sync.Once
+)
+
+func NewOIDCUsecase(client *oidc.Client, oidcStateRepo repository.IOIDCStateRepository, userIdentityRepo repository.IUserIdentityRepository, userRepo repository.IUserRepository) IOIDCUsecase {
+	oidcUsecaseOnce.Do(func() {
+		oidcUsecaseInstance = &OIDCUsecase{
+			Client:           client,
+			OIDCStateRepo:    oidcStateRepo,
+			UserIdentityRepo: userIdentityRepo,
+			UserRepo:         userRepo,
+		}
+	})
+	return oidcUsecaseInstance
+}
+
+// BeginLogin starts a login at the provider and returns the URL to send the
+// user to.
+func (uc *OIDCUsecase) BeginLogin(ctx context.Context) (string, error) {
+	state, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := uc.Client.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	pending := &domain.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(oidcLoginTTL),
+	}
+	if err := uc.OIDCStateRepo.Save(pending); err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+// CompleteLogin handles the provider's redirect and returns the local user
+// the provider account belongs to. A provider account seen for the first
+// time is linked to the local user with the same verified email; accounts
+// without a local user are refused rather than created.
+func (uc *OIDCUsecase) CompleteLogin(ctx context.Context, state, code string) (*domain.User, error) {
+	pending, err := uc.OIDCStateRepo.Take(state)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil || time.Now().After(pending.ExpiresAt) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	claims, err := uc.Client.ExchangeCode(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+
+	user, err := uc.userForIdentity(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, domain.ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func (uc *OIDCUsecase) userForIdentity(claims *oidc.IDTokenClaims) (*domain.User, error) {
+	identity, err := uc.UserIdentityRepo.GetByIssuerAndSubject(claims.Issuer, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := uc.UserRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, domain.ErrNotFound
+		}
+		return user, nil
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, domain.ErrNotFound
+	}
+
+	user, err := uc.UserRepo.GetByEmail(claims.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	identity = &domain.UserIdentity{UserID: user.ID, Issuer: claims.Issuer, Subject: claims.Subject}
+	if err := uc.UserIdentityRepo.Create(identity); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
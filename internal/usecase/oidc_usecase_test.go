@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"golang-technical-test/config"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/oidc"
+	"testing"
+	"time"
+)
+
+type fakeOIDCProvider struct {
+	exchanges int
+}
+
+func (p *fakeOIDCProvider) Discover(ctx context.Context, issuer string) (*oidc.Discovery, error) {
+	return &oidc.Discovery{Issuer: issuer, AuthorizationEndpoint: issuer + "/authorize"}, nil
+}
+
+func (p *fakeOIDCProvider) Exchange(ctx context.Context, discovery *oidc.Discovery, request *oidc.TokenRequest) (*oidc.TokenResponse, error) {
+	p.exchanges++
+	return nil, errors.New("invalid_grant")
+}
+
+type fakeOIDCStateRepo struct {
+	states map[string]*domain.OIDCLoginState
+}
+
+func (r *fakeOIDCStateRepo) Save(state *domain.OIDCLoginState) error {
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *fakeOIDCStateRepo) Take(state string) (*domain.OIDCLoginState, error) {
+	pending := r.states[state]
+	delete(r.states, state)
+	return pending, nil
+}
+
+func TestOIDCCompleteLoginState(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     *domain.OIDCLoginState
+		exchanges int
+	}{
+		{"unknown state", nil, 0},
+		{"expired state", &domain.OIDCLoginState{State: "s", ExpiresAt: time.Now().Add(-time.Second)}, 0},
+		{"pending state", &domain.OIDCLoginState{State: "s", ExpiresAt: time.Now().Add(time.Minute)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeOIDCProvider{}
+			states := &fakeOIDCStateRepo{states: make(map[string]*domain.OIDCLoginState)}
+			if tt.state != nil {
+				states.states[tt.state.State] = tt.state
+			}
+			uc := &OIDCUsecase{
+				Client:        &oidc.Client{Config: &config.OIDCConfig{Issuer: "https://idp.example.com"}, Discoverer: provider, Exchanger: provider},
+				OIDCStateRepo: states,
+			}
+
+			if _, err := uc.CompleteLogin(context.Background(), "s", "code"); !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("CompleteLogin() = %v, want %v", err, domain.ErrInvalidToken)
+			}
+			// A state can't be used twice, so the code is never exchanged again.
+			if _, err := uc.CompleteLogin(context.Background(), "s", "code"); !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("CompleteLogin() again = %v, want %v", err, domain.ErrInvalidToken)
+			}
+			if provider.exchanges != tt.exchanges {
+				t.Errorf("codes exchanged = %d, want %d", provider.exchanges, tt.exchanges)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"fmt"
+	"golang-technical-test/config"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/mailer"
+	"golang-technical-test/utils"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type IPasswordResetUsecase interface {
+	RequestReset(email, ip string)
+	ConfirmReset(token string, password string) error
+}
+
+type PasswordResetUsecase struct {
+	PasswordResetTokenRepo repository.IPasswordResetTokenRepository
+	UserRepo               repository.IUserRepository
+	RefreshTokenRepo       repository.IRefreshTokenRepository
+	RevokedTokenRepo       repository.IRevokedTokenRepository
+	RequestAttemptRepo     repository.ILoginAttemptRepository
+	Mailer                 mailer.Mailer
+	Config                 *config.PasswordResetConfig
+}
+
+var (
+	passwordResetUsecaseInstance *PasswordResetUsecase
+	passwordResetUsecaseOnce     sync.Once
+)
+
+func NewPasswordResetUsecase(passwordResetTokenRepo repository.IPasswordResetTokenRepository, userRepo repository.IUserRepository, refreshTokenRepo repository.IRefreshTokenRepository, revokedTokenRepo repository.IRevokedTokenRepository, requestAttemptRepo repository.ILoginAttemptRepository, mailer mailer.Mailer, cfg *config.PasswordResetConfig) IPasswordResetUsecase {
+	passwordResetUsecaseOnce.Do(func() {
+		passwordResetUsecaseInstance = &PasswordResetUsecase{
+			PasswordResetTokenRepo: passwordResetTokenRepo,
+			UserRepo:               userRepo,
+			RefreshTokenRepo:       refreshTokenRepo,
+			RevokedTokenRepo:       revokedTokenRepo,
+			RequestAttemptRepo:     requestAttemptRepo,
+			Mailer:                 mailer,
+			Config:                 cfg,
+		}
+	})
+	return passwordResetUsecaseInstance
+}
+
+// RequestReset emails a reset link to the account with that address. The
+// account is looked up and the email sent in the background, and unknown
+// addresses, disabled accounts and throttled requests are ignored, so
+// callers can't find out which emails have an account, not even from the
+// response time. Failures are only logged.
+func (uc *PasswordResetUsecase) RequestReset(email, ip string) {
+	if email == "" {
+		return
+	}
+
+	allowed, err := uc.allowRequest(email, ip)
+	if err != nil {
+		log.Printf("password reset request not throttled: %v", err)
+		return
+	}
+	if !allowed {
+		return
+	}
+
+	go func() {
+		if err := uc.sendReset(email); err != nil {
+			log.Printf("password reset email not sent: %v", err)
+		}
+	}()
+}
+
+// allowRequest counts a reset request for the email and for the IP, and
+// reports whether both are within their limits.
+func (uc *PasswordResetUsecase) allowRequest(email, ip string) (bool, error) {
+	now := time.Now()
+
+	emailAllowed, err := uc.countRequest(resetEmailKey(email), uc.Config.MaxRequests, now)
+	if err != nil {
+		return false, err
+	}
+	ipAllowed, err := uc.countRequest(resetIPKey(ip), uc.Config.IPMaxRequests, now)
+	if err != nil {
+		return false, err
+	}
+	return emailAllowed && ipAllowed, nil
+}
+
+// countRequest records a request under key unless the key is locked.
+// Reaching maxRequests locks it for RequestWindow; 0 means no limit.
+func (uc *PasswordResetUsecase) countRequest(key string, maxRequests int, now time.Time) (bool, error) {
+	previous, err := uc.RequestAttemptRepo.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if previous != nil && now.Before(previous.LockedUntil) {
+		return false, nil
+	}
+	// Old requests, or the ones that led to an expired lock, no longer count.
+	if previous != nil && (now.Sub(previous.LastFailure) > uc.Config.RequestWindow || !previous.LockedUntil.IsZero()) {
+		if err := uc.RequestAttemptRepo.Reset(key); err != nil {
+			return false, err
+		}
+	}
+
+	attempts, err := uc.RequestAttemptRepo.RecordFailure(key, now)
+	if err != nil {
+		return false, err
+	}
+	if maxRequests > 0 && attempts.Failures >= maxRequests {
+		if err := uc.RequestAttemptRepo.Lock(key, now.Add(uc.Config.RequestWindow)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// sendReset creates a reset token for the account with that address and
+// emails it the link.
+func (uc *PasswordResetUsecase) sendReset(email string) error {
+	user, err := uc.UserRepo.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Disabled {
+		return nil
+	}
+
+	plain, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	token := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(plain),
+		ExpiresAt: time.Now().UTC().Add(uc.Config.TokenTTL),
+	}
+	if err := uc.PasswordResetTokenRepo.Create(token); err != nil {
+		return err
+	}
+
+	return uc.Mailer.Send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello %s,\n\nUse the following link to choose a new password. It expires in %s and can only be used once.\n\n%s?token=%s\n\nIf you didn't ask for a password reset you can ignore this email.\n",
+			user.Username, uc.Config.TokenTTL, uc.Config.URL, url.QueryEscape(plain)),
+	})
+}
+
+// ConfirmReset sets the new password of the token's owner. Every other
+// pending reset token of the user is consumed and the user's sessions and
+// access tokens are revoked.
+func (uc *PasswordResetUsecase) ConfirmReset(token string, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	current, err := uc.PasswordResetTokenRepo.GetByHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if current == nil || current.UsedAt != nil || now.After(current.ExpiresAt) {
+		return domain.ErrInvalidToken
+	}
+
+	ok, err := uc.PasswordResetTokenRepo.MarkUsed(current.ID, now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidToken
+	}
+
+	if err := uc.UserRepo.UpdatePassword(current.UserID, hash); err != nil {
+		return err
+	}
+
+	if err := uc.PasswordResetTokenRepo.MarkUsedByUserID(current.UserID, now); err != nil {
+		return err
+	}
+
+	if err := uc.RevokedTokenRepo.RevokeUser(current.UserID, revocationCutoff(now)); err != nil {
+		return err
+	}
+
+	return uc.RefreshTokenRepo.RevokeByUserID(current.UserID)
+}
+
+func resetEmailKey(email string) string {
+	return "reset:" + strings.ToLower(email)
+}
+
+func resetIPKey(ip string) string {
+	return "reset-ip:" + ip
+}
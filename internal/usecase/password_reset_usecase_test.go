@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"golang-technical-test/config"
+	"testing"
+	"time"
+)
+
+func TestPasswordResetCountRequest(t *testing.T) {
+	repo := newFakeLoginAttemptRepo()
+	uc := &PasswordResetUsecase{
+		RequestAttemptRepo: repo,
+		Config:             &config.PasswordResetConfig{MaxRequests: 3, IPMaxRequests: 20, RequestWindow: 15 * time.Minute},
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"first request", now, true},
+		{"second request", now.Add(time.Minute), true},
+		{"third request reaches the limit", now.Add(2 * time.Minute), true},
+		{"locked", now.Add(3 * time.Minute), false},
+		{"still locked", now.Add(16 * time.Minute), false},
+		{"lock expired", now.Add(18 * time.Minute), true},
+		{"counting again", now.Add(19 * time.Minute), true},
+		{"old requests forgotten", now.Add(40 * time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		got, err := uc.countRequest("reset:alice@example.com", uc.Config.MaxRequests, tt.at)
+		if err != nil {
+			t.Fatalf("%s: countRequest() error = %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: countRequest() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if failures := repo.attempts["reset:alice@example.com"].Failures; failures != 1 {
+		t.Errorf("requests counted after the window = %d, want 1", failures)
+	}
+}
+
+func TestPasswordResetAllowRequest(t *testing.T) {
+	uc := &PasswordResetUsecase{
+		RequestAttemptRepo: newFakeLoginAttemptRepo(),
+		Config:             &config.PasswordResetConfig{MaxRequests: 2, IPMaxRequests: 3, RequestWindow: 15 * time.Minute},
+	}
+
+	// One IP asking for many addresses is stopped by the IP limit.
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+	want := []bool{true, true, true, false}
+	for i, email := range emails {
+		got, err := uc.allowRequest(email, "10.0.0.1")
+		if err != nil {
+			t.Fatalf("allowRequest(%s) error = %v", email, err)
+		}
+		if got != want[i] {
+			t.Errorf("allowRequest(%s) = %v, want %v", email, got, want[i])
+		}
+	}
+
+	// Many IPs asking for one address are stopped by the email limit, whatever its case.
+	ips := []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"}
+	want = []bool{true, true, false}
+	for i, ip := range ips {
+		got, err := uc.allowRequest("Alice@Example.com", ip)
+		if err != nil {
+			t.Fatalf("allowRequest(%s) error = %v", ip, err)
+		}
+		if got != want[i] {
+			t.Errorf("allowRequest from %s = %v, want %v", ip, got, want[i])
+		}
+	}
+}
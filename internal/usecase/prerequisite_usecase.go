@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type IPrerequisiteUsecase interface {
+	GetByCourseID(courseID string) ([]*domain.Prerequisite, error)
+	Add(courseID string, prerequisite *domain.Prerequisite) error
+	Remove(courseID string, prerequisiteID string) error
+}
+
+type PrerequisiteUsecase struct {
+	PrerequisiteRepo repository.IPrerequisiteRepository
+	CourseRepo       repository.ICourseRepository
+}
+
+var (
+	prerequisiteUsecaseInstance *PrerequisiteUsecase
+	prerequisiteUsecaseOnce     sync.Once
+)
+
+func NewPrerequisiteUsecase(prerequisiteRepo repository.IPrerequisiteRepository, courseRepo repository.ICourseRepository) IPrerequisiteUsecase {
+	prerequisiteUsecaseOnce.Do(func() {
+		prerequisiteUsecaseInstance = &PrerequisiteUsecase{
+			PrerequisiteRepo: prerequisiteRepo,
+			CourseRepo:       courseRepo,
+		}
+	})
+	return prerequisiteUsecaseInstance
+}
+
+func (uc *PrerequisiteUsecase) GetByCourseID(courseID string) ([]*domain.Prerequisite, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	return uc.PrerequisiteRepo.GetByCourseID(intCourseID)
+}
+
+// Add makes prerequisite.PrerequisiteID a prerequisite of the course. It is
+// refused when the course is already, directly or not, a prerequisite of
+// that course, since nobody could ever enroll in either.
+func (uc *PrerequisiteUsecase) Add(courseID string, prerequisite *domain.Prerequisite) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	prerequisite.CourseID = intCourseID
+
+	if err := prerequisite.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(prerequisite.CourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+	if _, err := uc.CourseRepo.GetByID(prerequisite.PrerequisiteID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	exists, err := uc.PrerequisiteRepo.Exists(prerequisite.CourseID, prerequisite.PrerequisiteID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrConflict
+	}
+
+	all, err := uc.PrerequisiteRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	if requires(all, prerequisite.PrerequisiteID, prerequisite.CourseID) {
+		return domain.ErrPrerequisiteCycle
+	}
+
+	return uc.PrerequisiteRepo.Create(prerequisite)
+}
+
+func (uc *PrerequisiteUsecase) Remove(courseID string, prerequisiteID string) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	intPrerequisiteID, err := strconv.Atoi(prerequisiteID)
+	if err != nil {
+		return err
+	}
+
+	exists, err := uc.PrerequisiteRepo.Exists(intCourseID, intPrerequisiteID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+
+	return uc.PrerequisiteRepo.Delete(intCourseID, intPrerequisiteID)
+}
+
+// requires reports whether courseID is target or has target among its
+// prerequisites, following the prerequisite graph transitively.
+func requires(prerequisites []*domain.Prerequisite, courseID, target int) bool {
+	edges := make(map[int][]int)
+	for _, p := range prerequisites {
+		edges[p.CourseID] = append(edges[p.CourseID], p.PrerequisiteID)
+	}
+
+	visited := make(map[int]bool)
+	pending := []int{courseID}
+	for len(pending) > 0 {
+		course := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if course == target {
+			return true
+		}
+		if visited[course] {
+			continue
+		}
+		visited[course] = true
+		pending = append(pending, edges[course]...)
+	}
+	return false
+}
+
+// unmetPrerequisites returns the prerequisites not passed in grades, the
+// student's grades. A grade passes a prerequisite when it reaches its
+// minimum grade or, without one, when the course's grading scale says it
+// passes; with neither, any grade passes.
+func unmetPrerequisites(prerequisites []*domain.Prerequisite, grades []*domain.Grade) []domain.UnmetPrerequisite {
+	var unmet []domain.UnmetPrerequisite
+	for _, p := range prerequisites {
+		var best *float64
+		passed := false
+		for _, grade := range grades {
+			if grade.CourseID != p.PrerequisiteID {
+				continue
+			}
+			if best == nil || grade.Grade > *best {
+				value := grade.Grade
+				best = &value
+			}
+			if p.MinGrade != nil {
+				passed = passed || grade.Grade >= *p.MinGrade
+			} else {
+				passed = passed || grade.Passed == nil || *grade.Passed
+			}
+		}
+
+		if !passed {
+			unmet = append(unmet, domain.UnmetPrerequisite{CourseID: p.PrerequisiteID, MinGrade: p.MinGrade, BestGrade: best})
+		}
+	}
+	return unmet
+}
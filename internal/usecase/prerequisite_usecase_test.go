@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"testing"
+)
+
+type fakePrerequisiteRepo struct {
+	repository.IPrerequisiteRepository
+	prerequisites []*domain.Prerequisite
+}
+
+func (r *fakePrerequisiteRepo) GetAll() ([]*domain.Prerequisite, error) {
+	return r.prerequisites, nil
+}
+
+func (r *fakePrerequisiteRepo) Exists(courseID int, prerequisiteID int) (bool, error) {
+	for _, p := range r.prerequisites {
+		if p.CourseID == courseID && p.PrerequisiteID == prerequisiteID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakePrerequisiteRepo) Create(prerequisite *domain.Prerequisite) error {
+	r.prerequisites = append(r.prerequisites, prerequisite)
+	return nil
+}
+
+type fakeCourseRepo struct {
+	repository.ICourseRepository
+	courses map[int]*domain.Course
+}
+
+func (r *fakeCourseRepo) GetByID(id int) (*domain.Course, error) {
+	course, ok := r.courses[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return course, nil
+}
+
+func TestPrerequisiteUsecaseAdd(t *testing.T) {
+	courses := make(map[int]*domain.Course)
+	for id := 1; id <= 5; id++ {
+		courses[id] = &domain.Course{ID: id}
+	}
+
+	tests := []struct {
+		name           string
+		courseID       int
+		prerequisiteID int
+		err            error
+	}{
+		{"new chain link", 4, 3, nil},
+		{"unrelated courses", 5, 1, nil},
+		{"course requires itself", 2, 2, domain.ErrPrerequisiteCycle},
+		{"direct cycle", 1, 2, domain.ErrPrerequisiteCycle},
+		{"transitive cycle", 1, 3, domain.ErrPrerequisiteCycle},
+		{"already required", 3, 2, domain.ErrConflict},
+		{"unknown course", 9, 1, domain.ErrNotFound},
+		{"unknown prerequisite", 1, 9, domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Course 3 requires 2, which requires 1.
+			uc := &PrerequisiteUsecase{
+				PrerequisiteRepo: &fakePrerequisiteRepo{prerequisites: []*domain.Prerequisite{
+					{CourseID: 2, PrerequisiteID: 1},
+					{CourseID: 3, PrerequisiteID: 2},
+				}},
+				CourseRepo: &fakeCourseRepo{courses: courses},
+			}
+			err := uc.Add(strconv.Itoa(tt.courseID), &domain.Prerequisite{PrerequisiteID: tt.prerequisiteID})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Add(%d, %d) = %v, want %v", tt.courseID, tt.prerequisiteID, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRequires(t *testing.T) {
+	// 3 requires 2 and 2 requires 1; 5 and 6 already require each other.
+	prerequisites := []*domain.Prerequisite{
+		{CourseID: 2, PrerequisiteID: 1},
+		{CourseID: 3, PrerequisiteID: 2},
+		{CourseID: 4, PrerequisiteID: 1},
+		{CourseID: 5, PrerequisiteID: 6},
+		{CourseID: 6, PrerequisiteID: 5},
+	}
+
+	tests := []struct {
+		courseID, target int
+		want             bool
+	}{
+		{3, 2, true},
+		{3, 1, true},
+		{3, 3, true},
+		{1, 3, false},
+		{4, 2, false},
+		{5, 6, true},
+		{5, 1, false},
+	}
+
+	for _, tt := range tests {
+		if got := requires(prerequisites, tt.courseID, tt.target); got != tt.want {
+			t.Errorf("requires(%d, %d) = %v, want %v", tt.courseID, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestUnmetPrerequisites(t *testing.T) {
+	passed, failed := true, false
+	sixty := 60.0
+	prerequisites := []*domain.Prerequisite{
+		{CourseID: 10, PrerequisiteID: 1, MinGrade: &sixty},
+		{CourseID: 10, PrerequisiteID: 2},
+	}
+
+	tests := []struct {
+		name   string
+		grades []*domain.Grade
+		unmet  []int
+	}{
+		{"both passed", []*domain.Grade{{CourseID: 1, Grade: 70}, {CourseID: 2, Grade: 40, Passed: &passed}}, nil},
+		{"below the minimum grade", []*domain.Grade{{CourseID: 1, Grade: 55}, {CourseID: 2, Grade: 40, Passed: &passed}}, []int{1}},
+		{"failed on the course's scale", []*domain.Grade{{CourseID: 1, Grade: 60}, {CourseID: 2, Grade: 40, Passed: &failed}}, []int{2}},
+		{"passed on a retake", []*domain.Grade{{CourseID: 1, Grade: 30}, {CourseID: 1, Grade: 65}, {CourseID: 2, Grade: 40, Passed: &failed}, {CourseID: 2, Grade: 50, Passed: &passed}}, nil},
+		{"course without a scale", []*domain.Grade{{CourseID: 1, Grade: 60}, {CourseID: 2, Grade: 1}}, nil},
+		{"never taken", nil, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmet := unmetPrerequisites(prerequisites, tt.grades)
+			if len(unmet) != len(tt.unmet) {
+				t.Fatalf("unmetPrerequisites() = %+v, want courses %v", unmet, tt.unmet)
+			}
+			for i, u := range unmet {
+				if u.CourseID != tt.unmet[i] {
+					t.Errorf("unmet[%d] = course %d, want %d", i, u.CourseID, tt.unmet[i])
+				}
+			}
+		})
+	}
+
+	unmet := unmetPrerequisites(prerequisites, []*domain.Grade{{CourseID: 1, Grade: 30}, {CourseID: 1, Grade: 45}})
+	if len(unmet) == 0 || unmet[0].BestGrade == nil || *unmet[0].BestGrade != 45 {
+		t.Errorf("unmetPrerequisites() = %+v, want a best grade of 45 for course 1", unmet)
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/repository"
 	"strconv"
@@ -10,13 +11,16 @@ import (
 type IProfessorUsecase interface {
 	GetAll() ([]*domain.Professor, error)
 	GetByID(id string) (*domain.Professor, error)
-	Create(professor *domain.Professor) error
-	Update(professor *domain.Professor) error
-	Delete(id string) error
+	Create(actor *domain.Principal, professor *domain.Professor) error
+	Update(actor *domain.Principal, professor *domain.Professor) error
+	Delete(actor *domain.Principal, id string) error
 }
 
 type ProfessorUsecase struct {
-	ProfessorRepo repository.IProfessorRepository
+	ProfessorRepo  repository.IProfessorRepository
+	DepartmentRepo repository.IDepartmentRepository
+	AuditRepo      repository.IAuditRepository
+	Transactor     database.ITransactor
 }
 
 var (
@@ -24,10 +28,13 @@ var (
 	professorUsecaseOnce     sync.Once
 )
 
-func NewProfessorUsecase(repo repository.IProfessorRepository) IProfessorUsecase {
+func NewProfessorUsecase(repo repository.IProfessorRepository, departmentRepo repository.IDepartmentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IProfessorUsecase {
 	professorUsecaseOnce.Do(func() {
 		professorUsecaseInstance = &ProfessorUsecase{}
 		professorUsecaseInstance.ProfessorRepo = repo
+		professorUsecaseInstance.DepartmentRepo = departmentRepo
+		professorUsecaseInstance.AuditRepo = auditRepo
+		professorUsecaseInstance.Transactor = transactor
 	})
 	return professorUsecaseInstance
 }
@@ -44,27 +51,69 @@ func (u *ProfessorUsecase) GetByID(id string) (*domain.Professor, error) {
 	return u.ProfessorRepo.GetByID(intID)
 }
 
-func (u *ProfessorUsecase) Create(professor *domain.Professor) error {
+func (u *ProfessorUsecase) Create(actor *domain.Principal, professor *domain.Professor) error {
 	err := professor.Validate()
 	if err != nil {
 		return err
 	}
+	if err := u.checkDepartment(professor); err != nil {
+		return err
+	}
 
-	return u.ProfessorRepo.Create(professor)
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.ProfessorRepo.WithTx(tx).Create(professor); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityProfessor, professor.ID, nil, professor)
+	})
 }
 
-func (u *ProfessorUsecase) Update(professor *domain.Professor) error {
+func (u *ProfessorUsecase) Update(actor *domain.Principal, professor *domain.Professor) error {
 	err := professor.Validate()
 	if err != nil {
 		return err
 	}
-	return u.ProfessorRepo.Update(professor)
+	if err := u.checkDepartment(professor); err != nil {
+		return err
+	}
+
+	before, err := u.ProfessorRepo.GetByID(professor.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.ProfessorRepo.WithTx(tx).Update(professor); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityProfessor, professor.ID, before, professor)
+	})
+}
+
+// checkDepartment makes sure the professor's department exists.
+func (u *ProfessorUsecase) checkDepartment(professor *domain.Professor) error {
+	if professor.DepartmentID == nil {
+		return nil
+	}
+	_, err := u.DepartmentRepo.GetByID(*professor.DepartmentID)
+	return notFoundIfNoRows(err)
 }
 
-func (u *ProfessorUsecase) Delete(id string) error {
+func (u *ProfessorUsecase) Delete(actor *domain.Principal, id string) error {
 	intID, err := strconv.Atoi(id)
 	if err != nil {
 		return err
 	}
-	return u.ProfessorRepo.Delete(intID)
+
+	before, err := u.ProfessorRepo.GetByID(intID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.ProfessorRepo.WithTx(tx).Delete(intID); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityProfessor, intID, before, nil)
+	})
 }
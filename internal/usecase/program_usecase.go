@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type IProgramUsecase interface {
+	GetAll() ([]*domain.Program, error)
+	GetByID(id string) (*domain.Program, error)
+	Create(actor *domain.Principal, program *domain.Program) error
+	Update(actor *domain.Principal, program *domain.Program) error
+	Delete(actor *domain.Principal, id string) error
+	AssignStudent(actor *domain.Principal, studentID string, programID *int) (*domain.Student, error)
+	GetDegreeAudit(actor *domain.Principal, studentID string) (*domain.DegreeAudit, error)
+}
+
+type ProgramUsecase struct {
+	ProgramRepo      repository.IProgramRepository
+	DepartmentRepo   repository.IDepartmentRepository
+	CourseRepo       repository.ICourseRepository
+	StudentRepo      repository.IStudentRepository
+	GradeRepo        repository.IGradeRepository
+	GradingScaleRepo repository.IGradingScaleRepository
+	AuditRepo        repository.IAuditRepository
+	Transactor       database.ITransactor
+}
+
+var (
+	programUsecaseInstance *ProgramUsecase
+	programUsecaseOnce     sync.Once
+)
+
+func NewProgramUsecase(repo repository.IProgramRepository, departmentRepo repository.IDepartmentRepository, courseRepo repository.ICourseRepository, studentRepo repository.IStudentRepository, gradeRepo repository.IGradeRepository, gradingScaleRepo repository.IGradingScaleRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IProgramUsecase {
+	programUsecaseOnce.Do(func() {
+		programUsecaseInstance = &ProgramUsecase{
+			ProgramRepo:      repo,
+			DepartmentRepo:   departmentRepo,
+			CourseRepo:       courseRepo,
+			StudentRepo:      studentRepo,
+			GradeRepo:        gradeRepo,
+			GradingScaleRepo: gradingScaleRepo,
+			AuditRepo:        auditRepo,
+			Transactor:       transactor,
+		}
+	})
+	return programUsecaseInstance
+}
+
+func (uc *ProgramUsecase) GetAll() ([]*domain.Program, error) {
+	return uc.ProgramRepo.GetAll()
+}
+
+func (uc *ProgramUsecase) GetByID(id string) (*domain.Program, error) {
+	programID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	program, err := uc.ProgramRepo.GetByID(programID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return program, nil
+}
+
+func (uc *ProgramUsecase) Create(actor *domain.Principal, program *domain.Program) error {
+	if err := uc.check(program); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.ProgramRepo.WithTx(tx).Create(program); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityProgram, program.ID, nil, program)
+	})
+}
+
+// Update changes a program and replaces its course sets.
+func (uc *ProgramUsecase) Update(actor *domain.Principal, program *domain.Program) error {
+	if err := uc.check(program); err != nil {
+		return err
+	}
+
+	before, err := uc.ProgramRepo.GetByID(program.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.ProgramRepo.WithTx(tx).Update(program); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityProgram, program.ID, before, program)
+	})
+}
+
+// check validates the program and makes sure its department and courses
+// exist.
+func (uc *ProgramUsecase) check(program *domain.Program) error {
+	if err := program.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := uc.DepartmentRepo.GetByID(program.DepartmentID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+	for _, set := range program.CourseSets {
+		for _, courseID := range set.CourseIDs {
+			if _, err := uc.CourseRepo.GetByID(courseID); err != nil {
+				return notFoundIfNoRows(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Delete removes a program no student is assigned to.
+func (uc *ProgramUsecase) Delete(actor *domain.Principal, id string) error {
+	programID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.ProgramRepo.GetByID(programID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	used, err := uc.ProgramRepo.IsUsed(programID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.ProgramRepo.WithTx(tx).Delete(programID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityProgram, programID, before, nil)
+	})
+}
+
+// AssignStudent assigns the student to a program, or to none when
+// programID is nil. The change is audited as an update of the student.
+func (uc *ProgramUsecase) AssignStudent(actor *domain.Principal, studentID string, programID *int) (*domain.Student, error) {
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := uc.StudentRepo.GetByID(intStudentID)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, domain.ErrNotFound
+	}
+	if programID != nil {
+		if _, err := uc.ProgramRepo.GetByID(*programID); err != nil {
+			return nil, notFoundIfNoRows(err)
+		}
+	}
+
+	student := *before
+	student.ProgramID = programID
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.StudentRepo.WithTx(tx).SetProgram(intStudentID, programID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityStudent, student.ID, before, &student)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// GetDegreeAudit compares the courses the student passed with the
+// student's program. A grade counts as passed when the course's grading
+// scale says so, or always when the course has no scale.
+func (uc *ProgramUsecase) GetDegreeAudit(actor *domain.Principal, studentID string) (*domain.DegreeAudit, error) {
+	intStudentID, err := strconv.Atoi(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intStudentID); err != nil {
+		return nil, err
+	}
+
+	student, err := uc.StudentRepo.GetByID(intStudentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, domain.ErrNotFound
+	}
+	if student.ProgramID == nil {
+		return nil, domain.ErrNoProgram
+	}
+	program, err := uc.ProgramRepo.GetByID(*student.ProgramID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	grades, err := uc.GradeRepo.GetByStudentID(intStudentID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := describeGrades(uc.GradingScaleRepo, grades...); err != nil {
+		return nil, err
+	}
+	passed := make(map[int]bool)
+	for _, grade := range grades {
+		if grade.Passed == nil || *grade.Passed {
+			passed[grade.CourseID] = true
+		}
+	}
+
+	courses, err := uc.CourseRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	credits := make(map[int]int, len(courses))
+	for _, course := range courses {
+		credits[course.ID] = course.Credits
+	}
+
+	audit := degreeAudit(program, passed, credits)
+	audit.StudentID = intStudentID
+	return audit, nil
+}
+
+// degreeAudit checks each course set of the program against the passed
+// courses.
+func degreeAudit(program *domain.Program, passed map[int]bool, credits map[int]int) *domain.DegreeAudit {
+	audit := &domain.DegreeAudit{
+		ProgramID:   program.ID,
+		ProgramName: program.Name,
+		MinCredits:  program.MinCredits,
+		CourseSets:  make([]*domain.CourseSetAudit, 0, len(program.CourseSets)),
+	}
+	for courseID := range passed {
+		audit.EarnedCredits += credits[courseID]
+	}
+
+	audit.Complete = audit.EarnedCredits >= audit.MinCredits
+	for _, set := range program.CourseSets {
+		setAudit := &domain.CourseSetAudit{
+			Name:       set.Name,
+			Kind:       set.Kind,
+			MinCredits: set.MinCredits,
+			Passed:     []int{},
+			Remaining:  []int{},
+		}
+		for _, courseID := range set.CourseIDs {
+			if passed[courseID] {
+				setAudit.Passed = append(setAudit.Passed, courseID)
+				setAudit.EarnedCredits += credits[courseID]
+			} else {
+				setAudit.Remaining = append(setAudit.Remaining, courseID)
+			}
+		}
+
+		if set.Kind == domain.CourseSetRequired {
+			setAudit.Complete = len(setAudit.Remaining) == 0
+		} else {
+			setAudit.Complete = setAudit.EarnedCredits >= set.MinCredits
+		}
+		audit.Complete = audit.Complete && setAudit.Complete
+		audit.CourseSets = append(audit.CourseSets, setAudit)
+	}
+	return audit
+}
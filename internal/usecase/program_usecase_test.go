@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func TestDegreeAudit(t *testing.T) {
+	// Courses 1 and 2 are required, and 6 of the credits must come from
+	// courses 3, 4 and 5. Every course is worth 3 credits.
+	program := &domain.Program{
+		ID:         1,
+		Name:       "Computer Science",
+		MinCredits: 12,
+		CourseSets: []domain.CourseSet{
+			{Name: "core", Kind: domain.CourseSetRequired, CourseIDs: []int{1, 2}},
+			{Name: "electives", Kind: domain.CourseSetElective, MinCredits: 6, CourseIDs: []int{3, 4, 5}},
+		},
+	}
+	credits := map[int]int{1: 3, 2: 3, 3: 3, 4: 3, 5: 3, 9: 3}
+
+	tests := []struct {
+		name          string
+		passed        []int
+		earned        int
+		complete      bool
+		setsComplete  []bool
+		coreRemaining []int
+	}{
+		{"complete", []int{1, 2, 3, 4}, 12, true, []bool{true, true}, []int{}},
+		{"required course missing", []int{1, 3, 4, 9}, 12, false, []bool{false, true}, []int{2}},
+		{"too few elective credits", []int{1, 2, 3, 9}, 12, false, []bool{true, false}, []int{}},
+		{"nothing passed", nil, 0, false, []bool{false, false}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed := make(map[int]bool)
+			for _, courseID := range tt.passed {
+				passed[courseID] = true
+			}
+
+			audit := degreeAudit(program, passed, credits)
+			if audit.EarnedCredits != tt.earned || audit.Complete != tt.complete {
+				t.Errorf("degreeAudit() earned %d, complete %v, want %d, %v", audit.EarnedCredits, audit.Complete, tt.earned, tt.complete)
+			}
+			for i, set := range audit.CourseSets {
+				if set.Complete != tt.setsComplete[i] {
+					t.Errorf("set %s complete = %v, want %v", set.Name, set.Complete, tt.setsComplete[i])
+				}
+			}
+			if !reflect.DeepEqual(audit.CourseSets[0].Remaining, tt.coreRemaining) {
+				t.Errorf("core remaining = %v, want %v", audit.CourseSets[0].Remaining, tt.coreRemaining)
+			}
+		})
+	}
+
+	t.Run("minimum credits", func(t *testing.T) {
+		short := *program
+		short.MinCredits = 15
+		audit := degreeAudit(&short, map[int]bool{1: true, 2: true, 3: true, 4: true}, credits)
+		if audit.Complete {
+			t.Error("degreeAudit() complete with 12 of 15 credits")
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/utils"
+	"sync"
+	"time"
+)
+
+// refreshTokenTTL is how long a refresh token can wait before being exchanged.
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+type IRefreshTokenUsecase interface {
+	Issue(userID int) (string, error)
+	Rotate(token string) (*domain.User, string, error)
+	Revoke(token string) error
+}
+
+type RefreshTokenUsecase struct {
+	RefreshTokenRepo repository.IRefreshTokenRepository
+	UserRepo         repository.IUserRepository
+}
+
+var (
+	refreshTokenUsecaseInstance *RefreshTokenUsecase
+	refreshTokenUsecaseOnce     sync.Once
+)
+
+func NewRefreshTokenUsecase(refreshTokenRepo repository.IRefreshTokenRepository, userRepo repository.IUserRepository) IRefreshTokenUsecase {
+	refreshTokenUsecaseOnce.Do(func() {
+		refreshTokenUsecaseInstance = &RefreshTokenUsecase{
+			RefreshTokenRepo: refreshTokenRepo,
+			UserRepo:         userRepo,
+		}
+	})
+	return refreshTokenUsecaseInstance
+}
+
+// Issue starts a new token family for the user and returns its first token.
+func (uc *RefreshTokenUsecase) Issue(userID int) (string, error) {
+	familyID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.create(userID, familyID)
+}
+
+// Rotate consumes the given refresh token and returns its owner together with
+// a replacement token from the same family. Presenting a token that was
+// already consumed revokes the whole family, since it means it was stolen.
+func (uc *RefreshTokenUsecase) Rotate(token string) (*domain.User, string, error) {
+	current, err := uc.RefreshTokenRepo.GetByHash(utils.HashToken(token))
+	if err != nil {
+		return nil, "", err
+	}
+	if current == nil || current.Revoked {
+		return nil, "", domain.ErrInvalidToken
+	}
+
+	if current.UsedAt != nil {
+		return nil, "", uc.revokeReused(current.FamilyID)
+	}
+
+	now := time.Now().UTC()
+	if now.After(current.ExpiresAt) {
+		return nil, "", domain.ErrInvalidToken
+	}
+
+	consumed, err := uc.RefreshTokenRepo.MarkUsed(current.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !consumed {
+		return nil, "", uc.revokeReused(current.FamilyID)
+	}
+
+	user, err := uc.UserRepo.GetByID(current.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	if user == nil || user.Disabled {
+		if err := uc.RefreshTokenRepo.RevokeFamily(current.FamilyID); err != nil {
+			return nil, "", err
+		}
+		return nil, "", domain.ErrInvalidToken
+	}
+
+	next, err := uc.create(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, next, nil
+}
+
+// Revoke ends the session the refresh token belongs to.
+func (uc *RefreshTokenUsecase) Revoke(token string) error {
+	current, err := uc.RefreshTokenRepo.GetByHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return domain.ErrInvalidToken
+	}
+
+	return uc.RefreshTokenRepo.RevokeFamily(current.FamilyID)
+}
+
+func (uc *RefreshTokenUsecase) create(userID int, familyID string) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken := &domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(refreshTokenTTL),
+	}
+	if err := uc.RefreshTokenRepo.Create(refreshToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (uc *RefreshTokenUsecase) revokeReused(familyID string) error {
+	if err := uc.RefreshTokenRepo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	return domain.ErrTokenReused
+}
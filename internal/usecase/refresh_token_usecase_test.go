@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"testing"
+	"time"
+)
+
+type fakeRefreshTokenRepo struct {
+	tokens []*domain.RefreshToken
+}
+
+func (r *fakeRefreshTokenRepo) GetByHash(tokenHash string) (*domain.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRefreshTokenRepo) Create(token *domain.RefreshToken) error {
+	token.ID = len(r.tokens) + 1
+	copied := *token
+	r.tokens = append(r.tokens, &copied)
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil {
+			token.UsedAt = &usedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeFamily(familyID string) error {
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeByUserID(userID int) error {
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
+
+type fakeUserRepo struct {
+	repository.IUserRepository
+	users map[int]*domain.User
+}
+
+func (r *fakeUserRepo) GetByID(id int) (*domain.User, error) {
+	return r.users[id], nil
+}
+
+func newTestRefreshTokenUsecase() (*RefreshTokenUsecase, *fakeRefreshTokenRepo, *fakeUserRepo) {
+	tokens := &fakeRefreshTokenRepo{}
+	users := &fakeUserRepo{users: map[int]*domain.User{1: {ID: 1, Username: "alice", Role: domain.RoleStudent}}}
+	return &RefreshTokenUsecase{RefreshTokenRepo: tokens, UserRepo: users}, tokens, users
+}
+
+func TestRefreshTokenRotate(t *testing.T) {
+	uc, _, _ := newTestRefreshTokenUsecase()
+
+	first, err := uc.Issue(1)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	user, second, err := uc.Rotate(first)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if user.ID != 1 || second == "" || second == first {
+		t.Fatalf("Rotate() = %+v, %q, want user 1 and a new token", user, second)
+	}
+	if _, third, err := uc.Rotate(second); err != nil || third == "" {
+		t.Errorf("Rotate() of the replacement = %q, %v, want a new token", third, err)
+	}
+}
+
+func TestRefreshTokenRotateReuse(t *testing.T) {
+	uc, tokens, _ := newTestRefreshTokenUsecase()
+
+	first, err := uc.Issue(1)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	_, second, err := uc.Rotate(first)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	other, err := uc.Issue(1)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	if _, _, err := uc.Rotate(first); !errors.Is(err, domain.ErrTokenReused) {
+		t.Fatalf("Rotate() of a used token = %v, want %v", err, domain.ErrTokenReused)
+	}
+	// The whole family is revoked, including the token the thief or the
+	// user got from the first rotation, but other sessions are not.
+	if _, _, err := uc.Rotate(second); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Rotate() after reuse = %v, want %v", err, domain.ErrInvalidToken)
+	}
+	if _, _, err := uc.Rotate(other); err != nil {
+		t.Errorf("Rotate() of another session = %v, want nil", err)
+	}
+	for _, token := range tokens.tokens[:2] {
+		if !token.Revoked {
+			t.Errorf("token %d of the reused family not revoked", token.ID)
+		}
+	}
+}
+
+func TestRefreshTokenRotateInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(uc *RefreshTokenUsecase, tokens *fakeRefreshTokenRepo, users *fakeUserRepo)
+	}{
+		{"expired", func(uc *RefreshTokenUsecase, tokens *fakeRefreshTokenRepo, users *fakeUserRepo) {
+			tokens.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+		}},
+		{"revoked", func(uc *RefreshTokenUsecase, tokens *fakeRefreshTokenRepo, users *fakeUserRepo) {
+			tokens.tokens[0].Revoked = true
+		}},
+		{"disabled user", func(uc *RefreshTokenUsecase, tokens *fakeRefreshTokenRepo, users *fakeUserRepo) {
+			users.users[1].Disabled = true
+		}},
+		{"deleted user", func(uc *RefreshTokenUsecase, tokens *fakeRefreshTokenRepo, users *fakeUserRepo) {
+			delete(users.users, 1)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, tokens, users := newTestRefreshTokenUsecase()
+			token, err := uc.Issue(1)
+			if err != nil {
+				t.Fatalf("Issue() error = %v", err)
+			}
+			tt.setup(uc, tokens, users)
+
+			if _, _, err := uc.Rotate(token); !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("Rotate() = %v, want %v", err, domain.ErrInvalidToken)
+			}
+		})
+	}
+
+	uc, _, _ := newTestRefreshTokenUsecase()
+	if _, _, err := uc.Rotate("unknown"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Rotate() of an unknown token = %v, want %v", err, domain.ErrInvalidToken)
+	}
+}
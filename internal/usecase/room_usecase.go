@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type IRoomUsecase interface {
+	GetAll() ([]*domain.Room, error)
+	GetByID(id string) (*domain.Room, error)
+	Create(actor *domain.Principal, room *domain.Room) error
+	Update(actor *domain.Principal, room *domain.Room) error
+	Delete(actor *domain.Principal, id string) error
+	GetAvailable(filter *domain.RoomAvailabilityFilter) ([]*domain.Room, error)
+}
+
+type RoomUsecase struct {
+	RoomRepo    repository.IRoomRepository
+	MeetingRepo repository.IMeetingRepository
+	TermRepo    repository.ITermRepository
+	AuditRepo   repository.IAuditRepository
+	Transactor  database.ITransactor
+}
+
+var (
+	roomUsecaseInstance *RoomUsecase
+	roomUsecaseOnce     sync.Once
+)
+
+func NewRoomUsecase(repo repository.IRoomRepository, meetingRepo repository.IMeetingRepository, termRepo repository.ITermRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IRoomUsecase {
+	roomUsecaseOnce.Do(func() {
+		roomUsecaseInstance = &RoomUsecase{
+			RoomRepo:    repo,
+			MeetingRepo: meetingRepo,
+			TermRepo:    termRepo,
+			AuditRepo:   auditRepo,
+			Transactor:  transactor,
+		}
+	})
+	return roomUsecaseInstance
+}
+
+func (uc *RoomUsecase) GetAll() ([]*domain.Room, error) {
+	return uc.RoomRepo.GetAll()
+}
+
+func (uc *RoomUsecase) GetByID(id string) (*domain.Room, error) {
+	roomID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	room, err := uc.RoomRepo.GetByID(roomID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return room, nil
+}
+
+func (uc *RoomUsecase) Create(actor *domain.Principal, room *domain.Room) error {
+	if err := room.Validate(); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.RoomRepo.WithTx(tx).Create(room); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityRoom, room.ID, nil, room)
+	})
+}
+
+// Update changes a room. Meetings already booked in it are not checked
+// against the new capacity.
+func (uc *RoomUsecase) Update(actor *domain.Principal, room *domain.Room) error {
+	if err := room.Validate(); err != nil {
+		return err
+	}
+
+	before, err := uc.RoomRepo.GetByID(room.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.RoomRepo.WithTx(tx).Update(room); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityRoom, room.ID, before, room)
+	})
+}
+
+// Delete removes a room no meeting is held in.
+func (uc *RoomUsecase) Delete(actor *domain.Principal, id string) error {
+	roomID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.RoomRepo.GetByID(roomID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	used, err := uc.RoomRepo.IsUsed(roomID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return domain.ErrConflict
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.RoomRepo.WithTx(tx).Delete(roomID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityRoom, roomID, before, nil)
+	})
+}
+
+// GetAvailable lists the rooms that match the filter and have no meeting
+// at that time in the term, the active one by default.
+func (uc *RoomUsecase) GetAvailable(filter *domain.RoomAvailabilityFilter) ([]*domain.Room, error) {
+	slot := &domain.Meeting{Day: filter.Day, StartTime: filter.StartTime, EndTime: filter.EndTime}
+	if err := slot.Validate(); err != nil {
+		return nil, err
+	}
+	var err error
+	if slot.TermID, err = resolveTerm(uc.TermRepo, filter.TermID); err != nil {
+		return nil, err
+	}
+
+	rooms, err := uc.RoomRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]*domain.Room, 0)
+	for _, room := range rooms {
+		if room.Capacity < filter.MinCapacity || !room.HasFeatures(filter.Features) {
+			continue
+		}
+		booking, err := roomBooking(uc.MeetingRepo, room.ID, slot)
+		if err != nil {
+			return nil, err
+		}
+		if booking == nil {
+			available = append(available, room)
+		}
+	}
+	return available, nil
+}
+
+// roomBooking returns the meeting, other than meeting itself, held in the
+// room in the same term at a time that overlaps meeting, or nil.
+func roomBooking(meetingRepo repository.IMeetingRepository, roomID int, meeting *domain.Meeting) (*domain.Meeting, error) {
+	booked, err := meetingRepo.GetByRoomID(roomID, meeting.TermID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range booked {
+		if b.ID != meeting.ID && b.Overlaps(meeting) {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
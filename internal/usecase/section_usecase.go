@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type ISectionUsecase interface {
+	GetByCourseID(courseID string, termID string) ([]*domain.Section, error)
+	Create(actor *domain.Principal, courseID string, section *domain.Section) error
+	Update(actor *domain.Principal, courseID string, section *domain.Section) error
+	Delete(actor *domain.Principal, courseID string, sectionID string) error
+	GetWaitlist(courseID string, sectionID string) ([]*domain.Enrollment, error)
+}
+
+type SectionUsecase struct {
+	SectionRepo    repository.ISectionRepository
+	CourseRepo     repository.ICourseRepository
+	TermRepo       repository.ITermRepository
+	EnrollmentRepo repository.IEnrollmentRepository
+	StudentRepo    repository.IStudentRepository
+	MeetingRepo    repository.IMeetingRepository
+	AuditRepo      repository.IAuditRepository
+	Transactor     database.ITransactor
+}
+
+var (
+	sectionUsecaseInstance *SectionUsecase
+	sectionUsecaseOnce     sync.Once
+)
+
+func NewSectionUsecase(repo repository.ISectionRepository, courseRepo repository.ICourseRepository, termRepo repository.ITermRepository, enrollmentRepo repository.IEnrollmentRepository, studentRepo repository.IStudentRepository, meetingRepo repository.IMeetingRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) ISectionUsecase {
+	sectionUsecaseOnce.Do(func() {
+		sectionUsecaseInstance = &SectionUsecase{
+			SectionRepo:    repo,
+			CourseRepo:     courseRepo,
+			TermRepo:       termRepo,
+			EnrollmentRepo: enrollmentRepo,
+			StudentRepo:    studentRepo,
+			MeetingRepo:    meetingRepo,
+			AuditRepo:      auditRepo,
+			Transactor:     transactor,
+		}
+	})
+	return sectionUsecaseInstance
+}
+
+func (uc *SectionUsecase) GetByCourseID(courseID string, termID string) ([]*domain.Section, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+	intTermID, err := parseTermFilter(termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(intCourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	return uc.SectionRepo.GetByCourseID(intCourseID, intTermID)
+}
+
+// Create adds a section to the course, in the active term unless the
+// section names one.
+func (uc *SectionUsecase) Create(actor *domain.Principal, courseID string, section *domain.Section) error {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+	section.CourseID = intCourseID
+
+	if err := section.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := uc.CourseRepo.GetByID(section.CourseID); err != nil {
+		return notFoundIfNoRows(err)
+	}
+	if section.TermID, err = resolveTerm(uc.TermRepo, section.TermID); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.SectionRepo.WithTx(tx).Create(section); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntitySection, section.ID, nil, section)
+	})
+}
+
+// Update changes the name, capacity and waitlist option of a section. Seats
+// freed by a larger capacity go to the waitlisted students in order, skipping
+// those who can no longer take them; see promotable.
+func (uc *SectionUsecase) Update(actor *domain.Principal, courseID string, section *domain.Section) error {
+	before, err := uc.section(courseID, section.ID)
+	if err != nil {
+		return err
+	}
+	section.CourseID = before.CourseID
+	section.TermID = before.TermID
+
+	if err := section.Validate(); err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		auditRepo := uc.AuditRepo.WithTx(tx)
+		if err := uc.SectionRepo.WithTx(tx).Update(section); err != nil {
+			return err
+		}
+		if err := recordAudit(auditRepo, actor, domain.AuditActionUpdate, domain.AuditEntitySection, section.ID, before, section); err != nil {
+			return err
+		}
+
+		enrollmentRepo := uc.EnrollmentRepo.WithTx(tx)
+		check := promotable(uc.StudentRepo.WithTx(tx), uc.MeetingRepo.WithTx(tx), enrollmentRepo)
+		promoted, err := enrollmentRepo.FillSection(section.ID, check)
+		if err != nil {
+			return err
+		}
+		return recordPromotions(auditRepo, actor, promoted)
+	})
+}
+
+// Delete removes a section. Sections with enrollments can't be deleted.
+func (uc *SectionUsecase) Delete(actor *domain.Principal, courseID string, sectionID string) error {
+	intSectionID, err := strconv.Atoi(sectionID)
+	if err != nil {
+		return err
+	}
+
+	before, err := uc.section(courseID, intSectionID)
+	if err != nil {
+		return err
+	}
+
+	enrollments, err := uc.EnrollmentRepo.GetBySectionID(before.ID)
+	if err != nil {
+		return err
+	}
+	if len(enrollments) > 0 {
+		return domain.ErrConflict
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.SectionRepo.WithTx(tx).Delete(before.ID); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntitySection, before.ID, before, nil)
+	})
+}
+
+// GetWaitlist returns the waitlisted enrollments of a section in the order
+// they will be promoted.
+func (uc *SectionUsecase) GetWaitlist(courseID string, sectionID string) ([]*domain.Enrollment, error) {
+	intSectionID, err := strconv.Atoi(sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := uc.section(courseID, intSectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments, err := uc.EnrollmentRepo.GetBySectionID(section.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var waitlist []*domain.Enrollment
+	for _, enrollment := range enrollments {
+		if enrollment.Status == domain.EnrollmentStatusWaitlisted {
+			waitlist = append(waitlist, enrollment)
+		}
+	}
+	return waitlist, nil
+}
+
+// section returns the section, which must belong to the course.
+func (uc *SectionUsecase) section(courseID string, sectionID int) (*domain.Section, error) {
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := uc.SectionRepo.GetByID(sectionID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if section.CourseID != intCourseID {
+		return nil, domain.ErrNotFound
+	}
+	return section, nil
+}
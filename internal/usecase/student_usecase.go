@@ -2,22 +2,28 @@ package usecase
 
 import (
 	"fmt"
+	"golang-technical-test/database"
 	"golang-technical-test/internal/domain"
 	"golang-technical-test/internal/repository"
 	"strconv"
 	"sync"
+	"time"
 )
 
 type IStudentUsecase interface {
 	GetAll() ([]*domain.Student, error)
 	GetByID(id string) (*domain.Student, error)
-	Create(student *domain.Student) error
-	Update(student *domain.Student) error
-	Delete(id string) error
+	Create(actor *domain.Principal, student *domain.Student) error
+	Update(actor *domain.Principal, student *domain.Student) error
+	Delete(actor *domain.Principal, id string) error
+	ChangeStatus(actor *domain.Principal, id string, change *domain.StudentStatusChange) (*domain.Student, error)
+	GetStatusHistory(actor *domain.Principal, id string) ([]*domain.StudentStatusChange, error)
 }
 
 type StudentUsecase struct {
 	StudentRepo repository.IStudentRepository
+	AuditRepo   repository.IAuditRepository
+	Transactor  database.ITransactor
 }
 
 var (
@@ -25,10 +31,12 @@ var (
 	once                   sync.Once
 )
 
-func NewStudentUsecase(repo repository.IStudentRepository) IStudentUsecase {
+func NewStudentUsecase(repo repository.IStudentRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) IStudentUsecase {
 	once.Do(func() {
 		studentUsecaseInstance = &StudentUsecase{
 			StudentRepo: repo,
+			AuditRepo:   auditRepo,
+			Transactor:  transactor,
 		}
 	})
 	return studentUsecaseInstance
@@ -47,24 +55,150 @@ func (uc *StudentUsecase) GetByID(id string) (*domain.Student, error) {
 	return uc.StudentRepo.GetByID(intID)
 }
 
-func (uc *StudentUsecase) Create(student *domain.Student) error {
+func (uc *StudentUsecase) Create(actor *domain.Principal, student *domain.Student) error {
 	err := student.Validate()
 	if err != nil {
 		return fmt.Errorf("error validating student data: %v", err)
 	}
+	student.ProgramID = nil
+	student.Status = domain.StudentStatusApplicant
 
-	return uc.StudentRepo.Create(student)
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.StudentRepo.WithTx(tx).Create(student); err != nil {
+			return err
+		}
+
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityStudent, student.ID, nil, student)
+	})
 }
 
-func (uc *StudentUsecase) Update(student *domain.Student) error {
-	return uc.StudentRepo.Update(student)
+func (uc *StudentUsecase) Update(actor *domain.Principal, student *domain.Student) error {
+	before, err := uc.existing(student.ID)
+	if err != nil {
+		return err
+	}
+	student.ProgramID = before.ProgramID
+	student.Status = before.Status
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.StudentRepo.WithTx(tx).Update(student); err != nil {
+			return err
+		}
+
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityStudent, student.ID, before, student)
+	})
 }
 
-func (uc *StudentUsecase) Delete(id string) error {
+func (uc *StudentUsecase) Delete(actor *domain.Principal, id string) error {
 	intID, err := strconv.Atoi(id)
 	if err != nil {
 		return err
 	}
 
-	return uc.StudentRepo.Delete(intID)
+	before, err := uc.existing(intID)
+	if err != nil {
+		return err
+	}
+
+	return uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.StudentRepo.WithTx(tx).Delete(intID); err != nil {
+			return err
+		}
+
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityStudent, intID, before, nil)
+	})
+}
+
+// ChangeStatus moves the student to change.ToStatus, if the lifecycle
+// allows it, and records the change with its reason.
+func (uc *StudentUsecase) ChangeStatus(actor *domain.Principal, id string, change *domain.StudentStatusChange) (*domain.Student, error) {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	before, err := uc.existing(intID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanChangeStatus(before.Status, change.ToStatus) {
+		return nil, fmt.Errorf("%w: %s to %s", domain.ErrStatusChange, before.Status, change.ToStatus)
+	}
+
+	change.StudentID = intID
+	change.FromStatus = before.Status
+	change.ActorUsername = actor.Username
+	if actor.UserID != 0 {
+		userID := actor.UserID
+		change.ActorUserID = &userID
+	}
+	change.ChangedAt = time.Now().UTC()
+	student := *before
+	student.Status = change.ToStatus
+	err = uc.Transactor.Transaction(func(tx *database.Database) error {
+		if err := uc.StudentRepo.WithTx(tx).ChangeStatus(change); err != nil {
+			return err
+		}
+		return recordAudit(uc.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityStudent, intID, before, &student)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+func (uc *StudentUsecase) GetStatusHistory(actor *domain.Principal, id string) ([]*domain.StudentStatusChange, error) {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeStudentAccess(actor, intID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.existing(intID); err != nil {
+		return nil, err
+	}
+
+	return uc.StudentRepo.GetStatusHistory(intID)
+}
+
+// existing returns the student as stored, for the audit log.
+func (uc *StudentUsecase) existing(id int) (*domain.Student, error) {
+	student, err := uc.StudentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return student, nil
+}
+
+// studentStatus returns the status of the student, or ErrNotFound when the
+// student doesn't exist.
+func studentStatus(repo repository.IStudentRepository, studentID int) (string, error) {
+	student, err := repo.GetByID(studentID)
+	if err != nil {
+		return "", err
+	}
+	if student == nil {
+		return "", domain.ErrNotFound
+	}
+	return student.Status, nil
+}
+
+// checkGradesOpen rejects changes to the grades of a graduated student.
+func checkGradesOpen(repo repository.IStudentRepository, studentID int) error {
+	status, err := studentStatus(repo, studentID)
+	if err != nil {
+		return err
+	}
+	if status == domain.StudentStatusGraduated {
+		return domain.ErrGradesFrozen
+	}
+	return nil
 }
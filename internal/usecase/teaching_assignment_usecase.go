@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type ITeachingAssignmentUsecase interface {
+	GetCoursesByProfessorID(professorID string) ([]*domain.Course, error)
+	Assign(professorID string, courseID int) (*domain.TeachingAssignment, error)
+	Unassign(professorID string, courseID string) error
+}
+
+type TeachingAssignmentUsecase struct {
+	TeachingAssignmentRepo repository.ITeachingAssignmentRepository
+	ProfessorRepo          repository.IProfessorRepository
+	CourseRepo             repository.ICourseRepository
+}
+
+var (
+	teachingAssignmentUsecaseInstance *TeachingAssignmentUsecase
+	teachingAssignmentUsecaseOnce     sync.Once
+)
+
+func NewTeachingAssignmentUsecase(teachingAssignmentRepo repository.ITeachingAssignmentRepository, professorRepo repository.IProfessorRepository, courseRepo repository.ICourseRepository) ITeachingAssignmentUsecase {
+	teachingAssignmentUsecaseOnce.Do(func() {
+		teachingAssignmentUsecaseInstance = &TeachingAssignmentUsecase{
+			TeachingAssignmentRepo: teachingAssignmentRepo,
+			ProfessorRepo:          professorRepo,
+			CourseRepo:             courseRepo,
+		}
+	})
+	return teachingAssignmentUsecaseInstance
+}
+
+func (uc *TeachingAssignmentUsecase) GetCoursesByProfessorID(professorID string) ([]*domain.Course, error) {
+	intProfessorID, err := strconv.Atoi(professorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.ProfessorRepo.GetByID(intProfessorID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	return uc.TeachingAssignmentRepo.GetCoursesByProfessorID(intProfessorID)
+}
+
+func (uc *TeachingAssignmentUsecase) Assign(professorID string, courseID int) (*domain.TeachingAssignment, error) {
+	intProfessorID, err := strconv.Atoi(professorID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := &domain.TeachingAssignment{ProfessorID: intProfessorID, CourseID: courseID}
+	if err := assignment.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.ProfessorRepo.GetByID(assignment.ProfessorID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	if _, err := uc.CourseRepo.GetByID(assignment.CourseID); err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+
+	exists, err := uc.TeachingAssignmentRepo.Exists(assignment.ProfessorID, assignment.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrConflict
+	}
+
+	if err := uc.TeachingAssignmentRepo.Create(assignment); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (uc *TeachingAssignmentUsecase) Unassign(professorID string, courseID string) error {
+	intProfessorID, err := strconv.Atoi(professorID)
+	if err != nil {
+		return err
+	}
+	intCourseID, err := strconv.Atoi(courseID)
+	if err != nil {
+		return err
+	}
+
+	exists, err := uc.TeachingAssignmentRepo.Exists(intProfessorID, intCourseID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+
+	return uc.TeachingAssignmentRepo.Delete(intProfessorID, intCourseID)
+}
+
+// notFoundIfNoRows turns the sql.ErrNoRows returned by the GetByID
+// repository methods into domain.ErrNotFound.
+func notFoundIfNoRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// actingProfessor returns the professor a grade is recorded for. Professors
+// act as the professor linked to their account and may not name another
+// one; admins may name any professor.
+func actingProfessor(actor *domain.Principal, professorID int) (int, error) {
+	if actor.HasRole(domain.RoleAdmin) {
+		return professorID, nil
+	}
+	if actor.ProfessorID == 0 || (professorID != 0 && professorID != actor.ProfessorID) {
+		return 0, domain.ErrForbidden
+	}
+	return actor.ProfessorID, nil
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"testing"
+)
+
+func TestActingProfessor(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       *domain.Principal
+		professorID int
+		want        int
+		err         error
+	}{
+		{"admin for a professor", &domain.Principal{Role: domain.RoleAdmin}, 3, 3, nil},
+		{"professor for themselves", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 3}, 3, 3, nil},
+		{"professor without naming one", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 3}, 0, 3, nil},
+		{"professor for a colleague", &domain.Principal{Role: domain.RoleProfessor, ProfessorID: 3}, 4, 0, domain.ErrForbidden},
+		{"account without a professor", &domain.Principal{Role: domain.RoleRegistrar}, 3, 0, domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := actingProfessor(tt.actor, tt.professorID)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Errorf("actingProfessor() = %d, %v, want %d, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"golang-technical-test/database"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+)
+
+type ITermUsecase interface {
+	GetAll() ([]*domain.Term, error)
+	GetByID(id string) (*domain.Term, error)
+	GetActive() (*domain.Term, error)
+	Create(actor *domain.Principal, term *domain.Term) error
+	Update(actor *domain.Principal, term *domain.Term) error
+	Delete(actor *domain.Principal, id string) error
+}
+
+type TermUsecase struct {
+	TermRepo   repository.ITermRepository
+	AuditRepo  repository.IAuditRepository
+	Transactor database.ITransactor
+}
+
+var (
+	termUsecaseInstance *TermUsecase
+	termUsecaseOnce     sync.Once
+)
+
+func NewTermUsecase(repo repository.ITermRepository, auditRepo repository.IAuditRepository, transactor database.ITransactor) ITermUsecase {
+	termUsecaseOnce.Do(func() {
+		termUsecaseInstance = &TermUsecase{
+			TermRepo:   repo,
+			AuditRepo:  auditRepo,
+			Transactor: transactor,
+		}
+	})
+	return termUsecaseInstance
+}
+
+func (u *TermUsecase) GetAll() ([]*domain.Term, error) {
+	return u.TermRepo.GetAll()
+}
+
+func (u *TermUsecase) GetByID(id string) (*domain.Term, error) {
+	termID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+	term, err := u.TermRepo.GetByID(termID)
+	if err != nil {
+		return nil, notFoundIfNoRows(err)
+	}
+	return term, nil
+}
+
+func (u *TermUsecase) GetActive() (*domain.Term, error) {
+	term, err := u.TermRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, domain.ErrNotFound
+	}
+	return term, nil
+}
+
+// Create stores a new term. Creating an active term deactivates the
+// previously active one.
+func (u *TermUsecase) Create(actor *domain.Principal, term *domain.Term) error {
+	if err := validateTerm(term); err != nil {
+		return err
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		termRepo := u.TermRepo.WithTx(tx)
+		if err := termRepo.Create(term); err != nil {
+			return err
+		}
+		if term.Active {
+			if err := termRepo.Activate(term.ID); err != nil {
+				return err
+			}
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionCreate, domain.AuditEntityTerm, term.ID, nil, term)
+	})
+}
+
+// Update changes a term. Setting Active deactivates every other term.
+func (u *TermUsecase) Update(actor *domain.Principal, term *domain.Term) error {
+	if err := validateTerm(term); err != nil {
+		return err
+	}
+
+	before, err := u.TermRepo.GetByID(term.ID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		termRepo := u.TermRepo.WithTx(tx)
+		if err := termRepo.Update(term); err != nil {
+			return err
+		}
+		switch {
+		case term.Active && !before.Active:
+			err = termRepo.Activate(term.ID)
+		case !term.Active && before.Active:
+			err = termRepo.Deactivate(term.ID)
+		}
+		if err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionUpdate, domain.AuditEntityTerm, term.ID, before, term)
+	})
+}
+
+func (u *TermUsecase) Delete(actor *domain.Principal, id string) error {
+	termID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	before, err := u.TermRepo.GetByID(termID)
+	if err != nil {
+		return notFoundIfNoRows(err)
+	}
+
+	return u.Transactor.Transaction(func(tx *database.Database) error {
+		if err := u.TermRepo.WithTx(tx).Delete(termID); err != nil {
+			return err
+		}
+		return recordAudit(u.AuditRepo.WithTx(tx), actor, domain.AuditActionDelete, domain.AuditEntityTerm, termID, before, nil)
+	})
+}
+
+func validateTerm(term *domain.Term) error {
+	if err := term.Validate(); err != nil {
+		return err
+	}
+	// Both dates are YYYY-MM-DD, so they compare as strings.
+	if term.EndDate <= term.StartDate {
+		return domain.ErrInvalidTermDates
+	}
+	return nil
+}
+
+// resolveTerm returns the term an enrollment or grade belongs to: the given
+// one, which must exist, or the active term when termID is 0.
+func resolveTerm(termRepo repository.ITermRepository, termID int) (int, error) {
+	if termID == 0 {
+		term, err := termRepo.GetActive()
+		if err != nil {
+			return 0, err
+		}
+		if term == nil {
+			return 0, domain.ErrNoActiveTerm
+		}
+		return term.ID, nil
+	}
+
+	if _, err := termRepo.GetByID(termID); err != nil {
+		return 0, notFoundIfNoRows(err)
+	}
+	return termID, nil
+}
+
+// parseTermFilter reads the optional term_id filter of the list endpoints.
+// An empty value means every term.
+func parseTermFilter(termID string) (int, error) {
+	if termID == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(termID)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type ITokenRevocationUsecase interface {
+	RevokeToken(jti string, expiresAt time.Time) error
+	RevokeUser(userID string) error
+}
+
+type TokenRevocationUsecase struct {
+	RevokedTokenRepo repository.IRevokedTokenRepository
+	RefreshTokenRepo repository.IRefreshTokenRepository
+	UserRepo         repository.IUserRepository
+}
+
+var (
+	tokenRevocationUsecaseInstance *TokenRevocationUsecase
+	tokenRevocationUsecaseOnce     sync.Once
+)
+
+func NewTokenRevocationUsecase(revokedTokenRepo repository.IRevokedTokenRepository, refreshTokenRepo repository.IRefreshTokenRepository, userRepo repository.IUserRepository) ITokenRevocationUsecase {
+	tokenRevocationUsecaseOnce.Do(func() {
+		tokenRevocationUsecaseInstance = &TokenRevocationUsecase{
+			RevokedTokenRepo: revokedTokenRepo,
+			RefreshTokenRepo: refreshTokenRepo,
+			UserRepo:         userRepo,
+		}
+	})
+	return tokenRevocationUsecaseInstance
+}
+
+// RevokeToken denies a single access token until it expires.
+func (uc *TokenRevocationUsecase) RevokeToken(jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return domain.ErrInvalidToken
+	}
+
+	return uc.RevokedTokenRepo.RevokeToken(jti, expiresAt)
+}
+
+// RevokeUser denies every access token issued to the user so far and ends
+// all of the user's refresh token sessions.
+func (uc *TokenRevocationUsecase) RevokeUser(userID string) error {
+	intID, err := strconv.Atoi(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.UserRepo.GetByID(intID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrNotFound
+	}
+
+	if err := uc.RevokedTokenRepo.RevokeUser(user.ID, revocationCutoff(time.Now())); err != nil {
+		return err
+	}
+
+	return uc.RefreshTokenRepo.RevokeByUserID(user.ID)
+}
+
+// revocationCutoff is the cut-off stored when every token of a user is
+// revoked at now. Tokens carry their issue time in whole seconds, so it is
+// the second now falls in; tokens issued up to and including that second are
+// denied, even those issued after now within the same second.
+func revocationCutoff(now time.Time) time.Time {
+	return now.UTC().Truncate(time.Second)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"testing"
+	"time"
+)
+
+func TestRevocationCutoff(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 700_000_000, time.UTC)
+	cutoff := revocationCutoff(now)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"issued earlier in the second", now.Add(-500 * time.Millisecond), true},
+		{"issued later in the same second", now.Add(200 * time.Millisecond), true},
+		{"issued the next second", now.Add(300 * time.Millisecond), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewInMemoryRevokedTokenRepository()
+			if err := repo.RevokeUser(1, cutoff); err != nil {
+				t.Fatalf("RevokeUser() error = %v", err)
+			}
+
+			// Access tokens carry their issue time in whole seconds.
+			revoked, err := repo.IsRevoked("jti", 1, time.Unix(tt.issuedAt.Unix(), 0))
+			if err != nil {
+				t.Fatalf("IsRevoked() error = %v", err)
+			}
+			if revoked != tt.revoked {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.revoked)
+			}
+		})
+	}
+}
+
+func TestTokenRevocationRevokeUser(t *testing.T) {
+	revokedTokens := repository.NewInMemoryRevokedTokenRepository()
+	refreshTokens := &fakeRefreshTokenRepo{tokens: []*domain.RefreshToken{{ID: 1, UserID: 1}, {ID: 2, UserID: 2}}}
+	uc := &TokenRevocationUsecase{
+		RevokedTokenRepo: revokedTokens,
+		RefreshTokenRepo: refreshTokens,
+		UserRepo:         &fakeUserRepo{users: map[int]*domain.User{1: {ID: 1}, 2: {ID: 2}}},
+	}
+
+	if err := uc.RevokeUser("1"); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+	issuedAt := time.Unix(time.Now().Unix(), 0)
+
+	if revoked, _ := revokedTokens.IsRevoked("jti", 1, issuedAt.Add(-time.Second)); !revoked {
+		t.Error("token issued before the revocation is not revoked")
+	}
+	if revoked, _ := revokedTokens.IsRevoked("jti", 2, issuedAt); revoked {
+		t.Error("token of another user is revoked")
+	}
+	if !refreshTokens.tokens[0].Revoked || refreshTokens.tokens[1].Revoked {
+		t.Errorf("refresh tokens revoked = %v, %v, want true, false", refreshTokens.tokens[0].Revoked, refreshTokens.tokens[1].Revoked)
+	}
+
+	if err := uc.RevokeUser("3"); err != domain.ErrNotFound {
+		t.Errorf("RevokeUser() of an unknown user = %v, want %v", err, domain.ErrNotFound)
+	}
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"fmt"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/internal/repository"
+	"golang-technical-test/utils"
+	"strconv"
+	"sync"
+)
+
+type IUserUsecase interface {
+	GetAll() ([]*domain.User, error)
+	GetByID(id string) (*domain.User, error)
+	Create(user *domain.User, password string) error
+	ChangeRole(id string, role string, studentID, professorID *int) error
+	ChangeEmail(id string, email string) error
+	Disable(id string) error
+	Enable(id string) error
+	ResetPassword(id string, password string) error
+	Authenticate(username, password string) (*domain.User, error)
+}
+
+type UserUsecase struct {
+	UserRepo repository.IUserRepository
+}
+
+var (
+	userUsecaseInstance *UserUsecase
+	userUsecaseOnce     sync.Once
+)
+
+// dummyPasswordHash is compared against when the username does not exist,
+// so a failed login takes the same time whether or not the user exists.
+const dummyPasswordHash = "$2a$10$jT9Zac7/yxgfx7ofhQvqF.c8cOPAwh/cdbbeEWDl9/F7UsYEN.29i"
+
+func NewUserUsecase(repo repository.IUserRepository) IUserUsecase {
+	userUsecaseOnce.Do(func() {
+		userUsecaseInstance = &UserUsecase{
+			UserRepo: repo,
+		}
+	})
+	return userUsecaseInstance
+}
+
+func (uc *UserUsecase) GetAll() ([]*domain.User, error) {
+	return uc.UserRepo.GetAll()
+}
+
+func (uc *UserUsecase) GetByID(id string) (*domain.User, error) {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.UserRepo.GetByID(intID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return user, nil
+}
+
+func (uc *UserUsecase) Create(user *domain.User, password string) error {
+	if user.Role != domain.RoleStudent {
+		user.StudentID = nil
+	}
+	if user.Role != domain.RoleProfessor {
+		user.ProfessorID = nil
+	}
+
+	err := user.Validate()
+	if err != nil {
+		return fmt.Errorf("error validating user data: %v", err)
+	}
+
+	existing, err := uc.UserRepo.GetByUsername(user.Username)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("username %q is already taken", user.Username)
+	}
+	if err := uc.checkEmailAvailable(user.Email, 0); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+
+	return uc.UserRepo.Create(user)
+}
+
+// ChangeRole sets the user's role. Student and professor accounts must be
+// linked to the student or professor record they belong to; other roles are
+// never linked.
+func (uc *UserUsecase) ChangeRole(id string, role string, studentID, professorID *int) error {
+	user, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	user.Role = role
+	user.StudentID = studentID
+	user.ProfessorID = professorID
+	if user.Role != domain.RoleStudent {
+		user.StudentID = nil
+	}
+	if user.Role != domain.RoleProfessor {
+		user.ProfessorID = nil
+	}
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("error validating user data: %v", err)
+	}
+
+	return uc.UserRepo.SetRole(user.ID, user.Role, user.StudentID, user.ProfessorID)
+}
+
+// ChangeEmail sets the address password reset links are sent to. An empty
+// email removes it.
+func (uc *UserUsecase) ChangeEmail(id string, email string) error {
+	user, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	user.Email = email
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("error validating user data: %v", err)
+	}
+	if err := uc.checkEmailAvailable(user.Email, user.ID); err != nil {
+		return err
+	}
+
+	return uc.UserRepo.SetEmail(user.ID, user.Email)
+}
+
+func (uc *UserUsecase) checkEmailAvailable(email string, userID int) error {
+	if email == "" {
+		return nil
+	}
+
+	existing, err := uc.UserRepo.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != userID {
+		return fmt.Errorf("email %q is already in use", email)
+	}
+
+	return nil
+}
+
+func (uc *UserUsecase) Disable(id string) error {
+	user, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.UserRepo.SetDisabled(user.ID, true)
+}
+
+func (uc *UserUsecase) Enable(id string) error {
+	user, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.UserRepo.SetDisabled(user.ID, false)
+}
+
+func (uc *UserUsecase) ResetPassword(id string, password string) error {
+	user, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return uc.UserRepo.UpdatePassword(user.ID, hash)
+}
+
+func (uc *UserUsecase) Authenticate(username, password string) (*domain.User, error) {
+	user, err := uc.UserRepo.GetByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		utils.CheckPassword(dummyPasswordHash, password)
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if user.Disabled {
+		return nil, domain.ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < utils.MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters long", utils.MinPasswordLength)
+	}
+
+	return utils.HashPassword(password)
+}
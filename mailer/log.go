@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"log"
+	"os"
+	"sync"
+)
+
+// LogMailer doesn't deliver anything: it appends every message to a file,
+// or writes it to the standard log when no file is set.
+type LogMailer struct {
+	from string
+	file string
+	mu   sync.Mutex
+}
+
+func NewLogMailer(from, file string) *LogMailer {
+	return &LogMailer{from: from, file: file}
+}
+
+func (m *LogMailer) Send(msg *Message) error {
+	data := formatMessage(m.from, msg)
+
+	if m.file == "" {
+		log.Printf("mail not sent (log mailer):\n%s", data)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, "\r\n\r\n"...)); err != nil {
+		return err
+	}
+
+	return nil
+}
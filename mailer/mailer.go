@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"fmt"
+	"golang-technical-test/config"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails on behalf of the API.
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// NewMailer
+// It creates the mailer selected by config.Driver: "smtp" sends through a
+// mail server, "log" writes the messages to a file (or the standard log) so
+// the flows that send email can be tried locally.
+func NewMailer(config *config.MailConfig) (Mailer, error) {
+	switch config.Driver {
+	case "smtp":
+		return NewSMTPMailer(config), nil
+	case "log", "":
+		return NewLogMailer(config.From, config.LogFile), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", config.Driver)
+	}
+}
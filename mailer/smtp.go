@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"fmt"
+	"golang-technical-test/config"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPMailer sends messages through an SMTP server.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(config *config.MailConfig) *SMTPMailer {
+	m := &SMTPMailer{
+		addr: net.JoinHostPort(config.Host, config.Port),
+		from: config.From,
+	}
+	if config.Username != "" {
+		m.auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(msg *Message) error {
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, formatMessage(m.from, msg))
+}
+
+// formatMessage renders the message with the headers a mail server expects.
+func formatMessage(from string, msg *Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
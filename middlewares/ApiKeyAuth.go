@@ -0,0 +1,28 @@
+package middlewares
+
+import "golang-technical-test/internal/domain"
+
+// APIKeyHeader is the header JWTAuthMiddleware reads an API key from.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves the API key sent in APIKeyHeader. It returns
+// domain.ErrInvalidToken for unknown or revoked keys.
+type APIKeyAuthenticator interface {
+	Authenticate(key string) (*domain.APIKey, error)
+}
+
+var apiKeyAuthenticator APIKeyAuthenticator
+
+// SetAPIKeyAuthenticator enables API keys as an alternative to Bearer tokens.
+// Without an authenticator the APIKeyHeader is ignored.
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
+func claimsForAPIKey(key *domain.APIKey) *Claims {
+	return &Claims{
+		Username: "api-key:" + key.Name,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}
+}
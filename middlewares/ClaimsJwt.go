@@ -3,7 +3,40 @@ package middlewares
 import "github.com/dgrijalva/jwt-go"
 
 // Claims struct
+// Requests authenticated with an API key carry the key's ID and scopes
+// instead of a user and role.
 type Claims struct {
-	Username string `json:"username"`
+	UserID      int      `json:"user_id"`
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	StudentID   int      `json:"student_id,omitempty"`
+	ProfessorID int      `json:"professor_id,omitempty"`
+	APIKeyID    int      `json:"api_key_id,omitempty"`
+	Scopes      []string `json:"scopes,omitempty"`
+	// Purpose restricts a token to a single step, such as PurposeMFAPending.
+	// JWTAuthMiddleware only accepts a token with a purpose on the routes
+	// registered for it with AcceptPurpose.
+	Purpose string `json:"purpose,omitempty"`
 	jwt.StandardClaims
 }
+
+// PurposeMFAPending marks the token returned by the first login step of a
+// user with two-factor authentication; it can only be traded for a full token.
+const PurposeMFAPending = "mfa_pending"
+
+// PurposeMFAEnrollment marks the token returned to a staff user who logs in
+// without two-factor authentication; it only reaches the enrollment routes.
+const PurposeMFAEnrollment = "mfa_enrollment"
+
+// HasRole reports whether the claims grant the given role.
+func (c *Claims) HasRole(role string) bool {
+	if c.Role == role {
+		return true
+	}
+	for _, scope := range c.Scopes {
+		if scope == role {
+			return true
+		}
+	}
+	return false
+}
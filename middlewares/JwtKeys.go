@@ -0,0 +1,246 @@
+package middlewares
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"golang-technical-test/config"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// signingKey is one configured JWT key. Keys without a private part can
+// only verify tokens, which is how a retired key is kept around until the
+// tokens it signed have expired.
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+var (
+	jwtKeys       = map[string]*signingKey{}
+	activeJWTKey  *signingKey
+	errUnknownKey = errors.New("unknown signing key")
+)
+
+// LoadKeys replaces the JWT keys with the ones in the configuration.
+// New tokens are signed with the key named by SigningKeyID; every listed key
+// is accepted when validating, selected by the token's "kid" header.
+func LoadKeys(cfg *config.JWTConfig) error {
+	if cfg == nil {
+		return errors.New("no JWT keys configured")
+	}
+
+	keys := make(map[string]*signingKey, len(cfg.Keys))
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return fmt.Errorf("jwt key %q: %v", keyCfg.ID, err)
+		}
+		if _, exists := keys[key.id]; exists {
+			return fmt.Errorf("jwt key %q is defined twice", key.id)
+		}
+		keys[key.id] = key
+	}
+
+	active, ok := keys[cfg.SigningKeyID]
+	if !ok {
+		return fmt.Errorf("signing key %q is not among the configured keys", cfg.SigningKeyID)
+	}
+	if active.signKey == nil {
+		return fmt.Errorf("signing key %q has no private key", cfg.SigningKeyID)
+	}
+
+	jwtKeys = keys
+	activeJWTKey = active
+	return nil
+}
+
+func loadKey(cfg config.JWTKeyConfig) (*signingKey, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("the key ID is required")
+	}
+
+	key := &signingKey{id: cfg.ID}
+	switch cfg.Algorithm {
+	case "HS256":
+		if cfg.Secret == "" {
+			return nil, errors.New("HS256 keys need a secret")
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(cfg.Secret)
+		key.verifyKey = []byte(cfg.Secret)
+	case "RS256":
+		key.method = jwt.SigningMethodRS256
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = private
+			key.verifyKey = &private.PublicKey
+		} else if cfg.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.verifyKey = public
+		} else {
+			return nil, errors.New("RS256 keys need a private or public key file")
+		}
+	case "EdDSA":
+		key.method = SigningMethodEdDSA
+		if cfg.PrivateKeyFile != "" {
+			private, err := parseEd25519PrivateKey(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = private
+			key.verifyKey = private.Public().(ed25519.PublicKey)
+		} else if cfg.PublicKeyFile != "" {
+			public, err := parseEd25519PublicKey(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			key.verifyKey = public
+		} else {
+			return nil, errors.New("EdDSA keys need a private or public key file")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	return key, nil
+}
+
+func readPEMBlock(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not PEM encoded", path)
+	}
+	return block.Bytes, nil
+}
+
+func parseEd25519PrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 private key", path)
+	}
+	return private, nil
+}
+
+func parseEd25519PublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	public, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 public key", path)
+	}
+	return public, nil
+}
+
+// keyForToken picks the verification key named by the token's "kid" header
+// and makes sure the token was signed with that key's algorithm.
+func keyForToken(token *jwt.Token) (interface{}, error) {
+	key := activeJWTKey
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = jwtKeys[kid]
+	}
+	if key == nil {
+		return nil, errUnknownKey
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet is the document published at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys returns the asymmetric verification keys. Shared HMAC secrets
+// are never published.
+func PublicKeys() JWKSet {
+	ids := make([]string, 0, len(jwtKeys))
+	for id := range jwtKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, id := range ids {
+		key := jwtKeys[id]
+		switch public := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+	return set
+}
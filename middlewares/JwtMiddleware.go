@@ -1,6 +1,9 @@
 package middlewares
 
 import (
+	"errors"
+	"golang-technical-test/internal/domain"
+	"golang-technical-test/utils"
 	"net/http"
 	"strings"
 	"time"
@@ -10,8 +13,41 @@ import (
 )
 
 // JWTAuthMiddleware function
-func JWTAuthMiddleware() gin.HandlerFunc {
+// It requires a valid token, or an API key in the X-API-Key header, on every
+// route except the public ones. A public
+// route is written as "METHOD /path" or just "/path" for any method, using
+// the route pattern as registered (for example "GET /courses/:id").
+func JWTAuthMiddleware(publicRoutes ...string) gin.HandlerFunc {
+	public := make(map[string]bool, len(publicRoutes))
+	for _, route := range publicRoutes {
+		public[route] = true
+	}
+
 	return func(c *gin.Context) {
+		route := c.FullPath()
+		// Unknown routes are left to the router so they answer 404.
+		if route == "" || public[route] || public[c.Request.Method+" "+route] {
+			c.Next()
+			return
+		}
+
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" && apiKeyAuthenticator != nil {
+			key, err := apiKeyAuthenticator.Authenticate(apiKey)
+			if err != nil {
+				if errors.Is(err, domain.ErrInvalidToken) {
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+				} else {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking API key"})
+				}
+				c.Abort()
+				return
+			}
+
+			setClaims(c, claimsForAPIKey(key))
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 
 		if authHeader == "" {
@@ -20,44 +56,147 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Split the auth header to separate "Bearer" and the token
-		headerParts := strings.Split(authHeader, " ")
-		if len(headerParts) != 2 {
+		tokenString, ok := ExtractBearerToken(authHeader)
+		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
-		tokenString := headerParts[1]
-
 		claims, err := ValidateToken(tokenString)
 
-		if err != nil {
+		if err != nil || (claims.Purpose != "" && purposeRoutes[c.Request.Method+" "+route] != claims.Purpose) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
-		c.Set("username", claims.Username)
+		if revocationStore != nil {
+			revoked, err := revocationStore.IsRevoked(claims.Id, claims.UserID, time.Unix(claims.IssuedAt, 0))
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
+
+		setClaims(c, claims)
 
 		c.Next()
 	}
 }
 
-var jwtKey = []byte("your_secret_key")
+// purposeRoutes maps each "METHOD /path" route to the token purpose it
+// accepts besides full access tokens.
+var purposeRoutes = map[string]string{}
+
+// AcceptPurpose lets JWTAuthMiddleware accept tokens with the given purpose
+// on the routes, written as "METHOD /path" with the route pattern as
+// registered. It is called while the routes are set up.
+func AcceptPurpose(purpose string, routes ...string) {
+	for _, route := range routes {
+		purposeRoutes[route] = purpose
+	}
+}
+
+func setClaims(c *gin.Context, claims *Claims) {
+	c.Set("claims", claims)
+	c.Set("user_id", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("role", claims.Role)
+}
+
+// ExtractBearerToken returns the token of an "Authorization: Bearer <token>" header.
+func ExtractBearerToken(authHeader string) (string, bool) {
+	// Split the auth header to separate "Bearer" and the token
+	headerParts := strings.Split(authHeader, " ")
+	if len(headerParts) != 2 {
+		return "", false
+	}
+
+	return headerParts[1], true
+}
+
+// AccessTokenTTL is how long an access token issued by CreateToken is valid.
+const AccessTokenTTL = 15 * time.Minute
+
+// MFAPendingTokenTTL is how long the user has to send the second factor.
+const MFAPendingTokenTTL = 5 * time.Minute
+
+// MFAEnrollmentTokenTTL is how long a staff user has to set up two-factor
+// authentication before logging in again.
+const MFAEnrollmentTokenTTL = 15 * time.Minute
 
 // CreateToken function
-func CreateToken(username string) (string, error) {
-	expirationTime := time.Now().Add(15 * time.Minute)
-	claims := &Claims{
-		Username: username,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
+// It signs the identity held in claims; the registered claims (ID, issue
+// and expiry times) are filled in here.
+func CreateToken(claims *Claims) (string, error) {
+	return signToken(claims, AccessTokenTTL)
+}
+
+// CreateMFAPendingToken returns the short-lived token that identifies the
+// user between the password and the second-factor login steps.
+func CreateMFAPendingToken(userID int, username string) (string, error) {
+	return signToken(&Claims{UserID: userID, Username: username, Purpose: PurposeMFAPending}, MFAPendingTokenTTL)
+}
+
+// CreateMFAEnrollmentToken returns the token that lets a staff user without
+// two-factor authentication reach the enrollment routes, and nothing else.
+func CreateMFAEnrollmentToken(userID int, username string, role string) (string, error) {
+	return signToken(&Claims{UserID: userID, Username: username, Role: role, Purpose: PurposeMFAEnrollment}, MFAEnrollmentTokenTTL)
+}
+
+// ValidateMFAPendingToken validates a token created by CreateMFAPendingToken.
+func ValidateMFAPendingToken(tokenString string) (*Claims, error) {
+	claims, err := ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != PurposeMFAPending {
+		return nil, jwt.ErrSignatureInvalid
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	// A pending token is revoked once used, so it can't be traded twice.
+	if revocationStore != nil {
+		revoked, err := revocationStore.IsRevoked(claims.Id, claims.UserID, time.Unix(claims.IssuedAt, 0))
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, jwt.ErrSignatureInvalid
+		}
+	}
+
+	return claims, nil
+}
+
+func signToken(claims *Claims, ttl time.Duration) (string, error) {
+	jti, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	expirationTime := now.Add(ttl)
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        jti,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expirationTime.Unix(),
+	}
+
+	if activeJWTKey == nil {
+		return "", errUnknownKey
+	}
+
+	token := jwt.NewWithClaims(activeJWTKey.method, claims)
+	token.Header["kid"] = activeJWTKey.id
+	tokenString, err := token.SignedString(activeJWTKey.signKey)
 
 	if err != nil {
 		return "", err
@@ -70,19 +209,14 @@ func CreateToken(username string) (string, error) {
 func ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyForToken)
 
 	if err != nil {
-		if err == jwt.ErrSignatureInvalid {
-			return nil, err
-		}
 		return nil, err
 	}
 
 	if !token.Valid {
-		return nil, err
+		return nil, jwt.ErrSignatureInvalid
 	}
 
 	return claims, nil
@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"golang-technical-test/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestJWTAuthMiddlewareMFAEnrollmentToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	if err := LoadKeys(&config.JWTConfig{SigningKeyID: "test", Keys: []config.JWTKeyConfig{{ID: "test", Algorithm: "HS256", Secret: "secret"}}}); err != nil {
+		t.Fatalf("LoadKeys() error = %v", err)
+	}
+	AcceptPurpose(PurposeMFAEnrollment, "POST /mfa/totp/enroll")
+
+	router := gin.New()
+	router.Use(JWTAuthMiddleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/mfa/totp/enroll", ok)
+	router.POST("/mfa/totp/disable", ok)
+	router.GET("/courses", ok)
+
+	enrollment, err := CreateMFAEnrollmentToken(1, "admin", "admin")
+	if err != nil {
+		t.Fatalf("CreateMFAEnrollmentToken() error = %v", err)
+	}
+	pending, err := CreateMFAPendingToken(1, "admin")
+	if err != nil {
+		t.Fatalf("CreateMFAPendingToken() error = %v", err)
+	}
+	full, err := CreateToken(&Claims{UserID: 1, Username: "admin", Role: "admin"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		status int
+	}{
+		{"enrollment token on the enrollment route", enrollment, http.MethodPost, "/mfa/totp/enroll", http.StatusOK},
+		{"enrollment token on another MFA route", enrollment, http.MethodPost, "/mfa/totp/disable", http.StatusUnauthorized},
+		{"enrollment token on the API", enrollment, http.MethodGet, "/courses", http.StatusUnauthorized},
+		{"pending token on the enrollment route", pending, http.MethodPost, "/mfa/totp/enroll", http.StatusUnauthorized},
+		{"full token on the enrollment route", full, http.MethodPost, "/mfa/totp/enroll", http.StatusOK},
+		{"full token on the API", full, http.MethodGet, "/courses", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
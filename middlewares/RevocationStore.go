@@ -0,0 +1,17 @@
+package middlewares
+
+import "time"
+
+// RevocationStore is consulted by JWTAuthMiddleware to reject access tokens
+// that were revoked before their expiry.
+type RevocationStore interface {
+	IsRevoked(jti string, userID int, issuedAt time.Time) (bool, error)
+}
+
+var revocationStore RevocationStore
+
+// SetRevocationStore sets the store JWTAuthMiddleware checks every token against.
+// Without a store only the signature and expiry of a token are checked.
+func SetRevocationStore(store RevocationStore) {
+	revocationStore = store
+}
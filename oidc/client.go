@@ -0,0 +1,160 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"golang-technical-test/config"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Discovery is the part of the provider metadata the login flow needs.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// TokenRequest is the authorization code exchange sent to the token endpoint.
+type TokenRequest struct {
+	Code         string
+	CodeVerifier string
+	RedirectURL  string
+	ClientID     string
+	ClientSecret string
+}
+
+// TokenResponse is the answer of the token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Discoverer loads the provider metadata of an issuer.
+type Discoverer interface {
+	Discover(ctx context.Context, issuer string) (*Discovery, error)
+}
+
+// TokenExchanger trades an authorization code for tokens.
+type TokenExchanger interface {
+	Exchange(ctx context.Context, discovery *Discovery, request *TokenRequest) (*TokenResponse, error)
+}
+
+// IDTokenVerifier checks the signature, issuer, audience and expiry of an ID
+// token and returns its claims.
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, discovery *Discovery, clientID, rawIDToken string) (*IDTokenClaims, error)
+}
+
+var ErrNonceMismatch = errors.New("id token nonce does not match the login request")
+
+// Client runs the authorization code flow with PKCE against one provider.
+// Discovery, the code exchange and the ID token verification are separate
+// so any of them can be replaced, for example by a mock provider in tests.
+type Client struct {
+	Config     *config.OIDCConfig
+	Discoverer Discoverer
+	Exchanger  TokenExchanger
+	Verifier   IDTokenVerifier
+
+	mu        sync.Mutex
+	discovery *Discovery
+}
+
+// NewClient returns a client that talks to the configured provider over HTTP.
+func NewClient(cfg *config.OIDCConfig) *Client {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	return &Client{
+		Config:     cfg,
+		Discoverer: &HTTPDiscoverer{HTTPClient: httpClient},
+		Exchanger:  &HTTPTokenExchanger{HTTPClient: httpClient},
+		Verifier:   NewJWKSVerifier(httpClient),
+	}
+}
+
+// Discovery returns the provider metadata. It is fetched on first use and
+// then kept, so the API starts even when the provider is unreachable.
+func (c *Client) Discovery(ctx context.Context) (*Discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	discovery, err := c.Discoverer.Discover(ctx, c.Config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	c.discovery = discovery
+
+	return discovery, nil
+}
+
+// AuthCodeURL returns the provider URL the user is sent to in order to log in.
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	discovery, err := c.Discovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := c.Config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email"}
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.Config.ClientID)
+	params.Set("redirect_uri", c.Config.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallenge(codeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// ExchangeCode trades the authorization code for an ID token, verifies it
+// and checks it answers the login request that used nonce.
+func (c *Client) ExchangeCode(ctx context.Context, code, codeVerifier, nonce string) (*IDTokenClaims, error) {
+	discovery, err := c.Discovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := c.Exchanger.Exchange(ctx, discovery, &TokenRequest{
+		Code:         code,
+		CodeVerifier: codeVerifier,
+		RedirectURL:  c.Config.RedirectURL,
+		ClientID:     c.Config.ClientID,
+		ClientSecret: c.Config.ClientSecret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	claims, err := c.Verifier.Verify(ctx, discovery, c.Config.ClientID, tokens.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,158 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"golang-technical-test/config"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	discoveries int
+	request     *TokenRequest
+	claims      *IDTokenClaims
+}
+
+func (p *fakeProvider) Discover(ctx context.Context, issuer string) (*Discovery, error) {
+	p.discoveries++
+	return &Discovery{Issuer: issuer, AuthorizationEndpoint: issuer + "/authorize?tenant=1"}, nil
+}
+
+func (p *fakeProvider) Exchange(ctx context.Context, discovery *Discovery, request *TokenRequest) (*TokenResponse, error) {
+	p.request = request
+	return &TokenResponse{IDToken: "id-token"}, nil
+}
+
+func (p *fakeProvider) Verify(ctx context.Context, discovery *Discovery, clientID, rawIDToken string) (*IDTokenClaims, error) {
+	return p.claims, nil
+}
+
+func newTestClient(provider *fakeProvider) *Client {
+	return &Client{
+		Config: &config.OIDCConfig{
+			Issuer:      "https://idp.example.com",
+			ClientID:    "app",
+			RedirectURL: "https://app.example.com/auth/oidc/callback",
+		},
+		Discoverer: provider,
+		Exchanger:  provider,
+		Verifier:   provider,
+	}
+}
+
+func TestClientAuthCodeURL(t *testing.T) {
+	provider := &fakeProvider{}
+	client := newTestClient(provider)
+
+	raw, err := client.AuthCodeURL(context.Background(), "the-state", "the-nonce", "the-verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	authURL, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("url.Parse(%s) error = %v", raw, err)
+	}
+
+	want := map[string]string{
+		"tenant":                "1",
+		"response_type":         "code",
+		"client_id":             "app",
+		"state":                 "the-state",
+		"nonce":                 "the-nonce",
+		"scope":                 "openid email",
+		"code_challenge":        CodeChallenge("the-verifier"),
+		"code_challenge_method": "S256",
+	}
+	query := authURL.Query()
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if query.Get("code_verifier") != "" {
+		t.Error("the code verifier is sent to the authorization endpoint")
+	}
+
+	if _, err := client.AuthCodeURL(context.Background(), "s", "n", "v"); err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	if provider.discoveries != 1 {
+		t.Errorf("discoveries = %d, want 1", provider.discoveries)
+	}
+}
+
+func TestClientExchangeCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		nonce string
+		err   error
+	}{
+		{"nonce of the login request", "the-nonce", nil},
+		{"nonce of another login", "other-nonce", ErrNonceMismatch},
+		{"no nonce", "", ErrNonceMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{claims: &IDTokenClaims{Subject: "123", Nonce: tt.nonce}}
+			client := newTestClient(provider)
+
+			_, err := client.ExchangeCode(context.Background(), "the-code", "the-verifier", "the-nonce")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ExchangeCode() = %v, want %v", err, tt.err)
+			}
+			if provider.request.CodeVerifier != "the-verifier" || provider.request.Code != "the-code" {
+				t.Errorf("token request = %+v, want the code and its verifier", provider.request)
+			}
+		})
+	}
+}
+
+func TestIDTokenClaimsValid(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims IDTokenClaims
+		valid  bool
+	}{
+		{"valid", IDTokenClaims{ExpiresAt: now.Add(time.Minute).Unix(), IssuedAt: now.Unix()}, true},
+		{"expired within the clock skew", IDTokenClaims{ExpiresAt: now.Add(-30 * time.Second).Unix()}, true},
+		{"expired", IDTokenClaims{ExpiresAt: now.Add(-2 * time.Minute).Unix()}, false},
+		{"no expiry", IDTokenClaims{}, false},
+		{"issued in the future", IDTokenClaims{ExpiresAt: now.Add(time.Hour).Unix(), IssuedAt: now.Add(5 * time.Minute).Unix()}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.claims.Valid(); (err == nil) != tt.valid {
+				t.Errorf("Valid() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestAudience(t *testing.T) {
+	tests := []struct {
+		json     string
+		contains bool
+	}{
+		{`"app"`, true},
+		{`["other", "app"]`, true},
+		{`"other"`, false},
+		{`[]`, false},
+	}
+
+	for _, tt := range tests {
+		var aud audience
+		if err := json.Unmarshal([]byte(tt.json), &aud); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", tt.json, err)
+		}
+		if got := aud.contains("app"); got != tt.contains {
+			t.Errorf("audience %s contains app = %v, want %v", tt.json, got, tt.contains)
+		}
+	}
+}
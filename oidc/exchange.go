@@ -0,0 +1,57 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPTokenExchanger posts the authorization code to the token endpoint,
+// authenticating the client with its secret in the request body.
+type HTTPTokenExchanger struct {
+	HTTPClient *http.Client
+}
+
+func (e *HTTPTokenExchanger) Exchange(ctx context.Context, discovery *Discovery, request *TokenRequest) (*TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", request.Code)
+	form.Set("redirect_uri", request.RedirectURL)
+	form.Set("client_id", request.ClientID)
+	form.Set("code_verifier", request.CodeVerifier)
+	if request.ClientSecret != "" {
+		form.Set("client_secret", request.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var providerErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		json.NewDecoder(resp.Body).Decode(&providerErr)
+		return nil, fmt.Errorf("oidc token exchange: %s %s %s", resp.Status, providerErr.Error, providerErr.ErrorDescription)
+	}
+
+	var tokens TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %v", err)
+	}
+
+	return &tokens, nil
+}
@@ -0,0 +1,18 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"golang-technical-test/utils"
+)
+
+// GenerateCodeVerifier returns a new PKCE code verifier (RFC 7636).
+func GenerateCodeVerifier() (string, error) {
+	return utils.GenerateRandomToken(32)
+}
+
+// CodeChallenge returns the S256 challenge sent for the code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
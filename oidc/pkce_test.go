@@ -0,0 +1,30 @@
+package oidc
+
+import "testing"
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B.
+	got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge() = %s, want %s", got, want)
+	}
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	first, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatalf("GenerateCodeVerifier() error = %v", err)
+	}
+	second, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatalf("GenerateCodeVerifier() error = %v", err)
+	}
+
+	// RFC 7636 allows 43 to 128 characters.
+	if len(first) < 43 || len(first) > 128 {
+		t.Errorf("len(verifier) = %d, want between 43 and 128", len(first))
+	}
+	if first == second {
+		t.Error("GenerateCodeVerifier() returned the same verifier twice")
+	}
+}
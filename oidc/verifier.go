@@ -0,0 +1,230 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// clockSkew is how far the provider's clock may be ahead or behind ours.
+const clockSkew = time.Minute
+
+// IDTokenClaims are the ID token claims used to find the local user.
+type IDTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	ExpiresAt     int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+}
+
+// Valid checks the expiry of the token; the issuer and audience are checked
+// by the verifier, which knows what to expect.
+func (c *IDTokenClaims) Valid() error {
+	now := time.Now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return errors.New("id token is expired")
+	}
+	if c.IssuedAt != 0 && now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("id token is issued in the future")
+	}
+	return nil
+}
+
+// audience accepts the "aud" claim both as a string and as a list.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// JWKSVerifier checks ID tokens signed with RS256 or ES256 against the keys
+// published at the provider's jwks_uri. Keys are cached and fetched again
+// when a token names an unknown key ID, which follows key rotations.
+type JWKSVerifier struct {
+	HTTPClient *http.Client
+
+	mu   sync.Mutex
+	uri  string
+	keys map[string]interface{}
+}
+
+func NewJWKSVerifier(httpClient *http.Client) *JWKSVerifier {
+	return &JWKSVerifier{HTTPClient: httpClient}
+}
+
+func (v *JWKSVerifier) Verify(ctx context.Context, discovery *Discovery, clientID, rawIDToken string) (*IDTokenClaims, error) {
+	claims := &IDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.key(ctx, discovery.JWKSURI, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if token.Method != jwt.SigningMethodRS256 {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+		case *ecdsa.PublicKey:
+			if token.Method != jwt.SigningMethodES256 {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oidc id token: %v", err)
+	}
+
+	if claims.Issuer != discovery.Issuer {
+		return nil, fmt.Errorf("oidc id token: issuer %q does not match %q", claims.Issuer, discovery.Issuer)
+	}
+	if !claims.Audience.contains(clientID) {
+		return nil, errors.New("oidc id token: not issued for this client")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("oidc id token: missing subject")
+	}
+
+	return claims, nil
+}
+
+func (v *JWKSVerifier) key(ctx context.Context, uri, kid string) (interface{}, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.uri == uri {
+		if key, ok := v.keys[kid]; ok {
+			return key, nil
+		}
+	}
+
+	keys, err := v.fetch(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	v.uri = uri
+	v.keys = keys
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context, uri string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching jwks: unexpected status %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("fetching jwks: %v", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			// Keys of types we don't support are skipped.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
@@ -0,0 +1,20 @@
+package utils
+
+import "golang.org/x/crypto/bcrypt"
+
+// MinPasswordLength is the shortest password accepted for a user account.
+const MinPasswordLength = 8
+
+// HashPassword returns the bcrypt hash of the given password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether the password matches the bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}